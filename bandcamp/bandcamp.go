@@ -16,7 +16,23 @@ const (
 
 type Band struct {
    Name string
+   Location string
+   Bio string
    Discography []Item
+   // only for labels
+   Artists []struct {
+      ID int
+      Name string
+   }
+}
+
+// Roster returns the artists of a label, as items with type "band".
+func (b Band) Roster() []Item {
+   items := make([]Item, len(b.Artists))
+   for i, artist := range b.Artists {
+      items[i] = Item{Band_ID: artist.ID, Item_Type: "band", Title: artist.Name}
+   }
+   return items
 }
 
 func new_band(id int) (*Band, error) {
@@ -106,7 +122,8 @@ func (i Image) URL(art_id int64) string {
 type Item struct {
    Band_ID int
    Item_ID int
-   Item_Type string
+   Item_Type string // album, track, band, label
+   Title string
 }
 
 func (i Item) Band() (*Band, error) {
@@ -127,11 +144,17 @@ type Track struct {
    Track_Num int64
    Title string
    Band_Name string
+   Duration float64 // seconds
+   Lyrics string
    Streaming_URL *struct {
       MP3_128 string `json:"mp3-128"`
    }
 }
 
+func (t Track) Length() time.Duration {
+   return time.Duration(t.Duration * float64(time.Second))
+}
+
 func (t Track) Base() string {
    return mech.Clean(t.Band_Name + "-" + t.Title)
 }
@@ -144,6 +167,8 @@ func (t Track) String() string {
    buf = append(buf, t.Title...)
    buf = append(buf, " Band:"...)
    buf = append(buf, t.Band_Name...)
+   buf = append(buf, " Duration:"...)
+   buf = append(buf, t.Length().String()...)
    if t.Streaming_URL != nil {
       buf = append(buf, " URL:"...)
       buf = append(buf, t.Streaming_URL.MP3_128...)
@@ -156,9 +181,34 @@ type Tralbum struct {
    Release_Date int64
    Title string
    Tralbum_Artist string
+   About string
+   Credits string
+   Label string
+   Tags []struct {
+      Name string
+   }
    Tracks []Track
 }
 
+// Metadata returns tags for a track of the album. Tralbum_Artist is the
+// album artist, and Band_Name of the track is the artist.
+func (t Tralbum) Metadata(track Track) mech.Tags {
+   tags := mech.Tags{
+      Album: t.Title,
+      Album_Artist: t.Tralbum_Artist,
+      Artist: track.Band_Name,
+      Title: track.Title,
+      Track_Number: int(track.Track_Num),
+   }
+   if t.Release_Date >= 1 {
+      tags.Date = strconv.Itoa(t.Date().Year())
+   }
+   if len(t.Tags) >= 1 {
+      tags.Genre = t.Tags[0].Name
+   }
+   return tags
+}
+
 func new_tralbum(typ byte, id int) (*Tralbum, error) {
    req, err := http.NewRequest(
       "GET", "http://bandcamp.com/api/mobile/24/tralbum_details", nil,
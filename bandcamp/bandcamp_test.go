@@ -13,3 +13,38 @@ func Test_Image(t *testing.T) {
       fmt.Println(addr)
    }
 }
+
+func Test_Metadata(t *testing.T) {
+   tralb := Tralbum{
+      Release_Date: 1577836800,
+      Title: "Passage",
+      Tralbum_Artist: "Schnauss and Munk",
+   }
+   tralb.Tags = append(tralb.Tags, struct{ Name string }{"ambient"})
+   tags := tralb.Metadata(Track{Band_Name: "Munk", Title: "Amaris", Track_Num: 2})
+   if tags.Date != "2020" || tags.Genre != "ambient" || tags.Album_Artist == tags.Artist {
+      t.Fatalf("%+v", tags)
+   }
+}
+
+func Test_Select_Image(t *testing.T) {
+   tests := []struct {
+      width, format int
+      crop bool
+      want int64
+   }{
+      {600, JPEG, false, 5},
+      {9999, JPEG, false, 0},
+      {1000, PNG, false, 31},
+      {700, JPEG, true, 27},
+   }
+   for _, test := range tests {
+      img, err := Select_Image(test.width, test.format, test.crop)
+      if err != nil {
+         t.Fatal(err)
+      }
+      if img.ID != test.want {
+         t.Fatal(test, img)
+      }
+   }
+}
@@ -0,0 +1,66 @@
+package bandcamp
+
+import (
+   "errors"
+   "net/http"
+   "os"
+)
+
+// smallest image at least width wide, or else the widest
+func Select_Image(width, format int, crop bool) (*Image, error) {
+   var out *Image
+   for i, img := range Images {
+      if img.Format != format || img.Crop != crop {
+         continue
+      }
+      switch {
+      case out == nil:
+      case out.Width < width:
+         // too small, so take anything bigger
+         if img.Width <= out.Width {
+            continue
+         }
+      case img.Width < width || img.Width >= out.Width:
+         continue
+      }
+      out = &Images[i]
+   }
+   if out == nil {
+      return nil, errors.New("image is missing")
+   }
+   return out, nil
+}
+
+func (i Image) Ext() string {
+   if i.Format == PNG {
+      return ".png"
+   }
+   return ".jpg"
+}
+
+// Cover saves the cover art to name plus the extension, and returns it.
+func (t Tralbum) Cover(name string, img Image) (string, error) {
+   req, err := http.NewRequest("GET", img.URL(t.Art_ID) + img.Ext(), nil)
+   if err != nil {
+      return "", err
+   }
+   Log.Dump(req)
+   res, err := new(http.Transport).RoundTrip(req)
+   if err != nil {
+      return "", err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return "", errors.New(res.Status)
+   }
+   name += img.Ext()
+   file, err := os.Create(name)
+   if err != nil {
+      return "", err
+   }
+   defer file.Close()
+   if _, err := file.ReadFrom(res.Body); err != nil {
+      return "", err
+   }
+   return name, nil
+}
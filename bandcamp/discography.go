@@ -0,0 +1,54 @@
+package bandcamp
+
+import (
+   "strconv"
+   "time"
+)
+
+// Item_Error is an album or track that could not be fetched.
+type Item_Error struct {
+   Item Item
+   Err error
+}
+
+func (i Item_Error) Error() string {
+   var buf []byte
+   buf = append(buf, i.Item.Item_Type...)
+   buf = append(buf, ' ')
+   buf = strconv.AppendInt(buf, int64(i.Item.Item_ID), 10)
+   buf = append(buf, ": "...)
+   buf = append(buf, i.Err.Error()...)
+   return string(buf)
+}
+
+func (i Item_Error) Unwrap() error {
+   return i.Err
+}
+
+// Scanner fetches each album and track of a band, one for each page, waiting
+// delay between. Releases that fail are added to Skipped.
+func (b Band) Scanner(delay time.Duration) *Scanner[Tralbum] {
+   items := b.Discography
+   var (
+      scan Scanner[Tralbum]
+      started bool
+   )
+   scan.next = func() ([]Tralbum, bool, error) {
+      for len(items) >= 1 {
+         if started {
+            time.Sleep(delay)
+         }
+         started = true
+         var item Item
+         item, items = items[0], items[1:]
+         tralb, err := item.Tralbum()
+         if err != nil {
+            scan.Skipped = append(scan.Skipped, Item_Error{item, err})
+            continue
+         }
+         return []Tralbum{*tralb}, len(items) >= 1, nil
+      }
+      return nil, false, nil
+   }
+   return &scan
+}
@@ -0,0 +1,51 @@
+package bandcamp
+
+import (
+   "fmt"
+   "testing"
+   "time"
+)
+
+func Test_Discography(t *testing.T) {
+   param, err := New_Params(tests[0])
+   if err != nil {
+      t.Fatal(err)
+   }
+   band, err := param.Band()
+   if err != nil {
+      t.Fatal(err)
+   }
+   scan := band.Scanner(99 * time.Millisecond)
+   for scan.Scan() {
+      for _, tralb := range scan.Page() {
+         fmt.Println(tralb.Title)
+      }
+   }
+   if err := scan.Err(); err != nil {
+      t.Fatal(err)
+   }
+   for _, skip := range scan.Skipped {
+      t.Error(skip)
+   }
+}
+
+func Test_Skipped(t *testing.T) {
+   band := Band{
+      Discography: []Item{
+         {Item_ID: 1, Item_Type: "band"},
+         {Item_ID: 2, Item_Type: "label"},
+      },
+   }
+   scan := band.Scanner(0)
+   for scan.Scan() {
+      if len(scan.Page()) >= 1 {
+         t.Fatal(scan.Page())
+      }
+   }
+   if err := scan.Err(); err != nil {
+      t.Fatal(err)
+   }
+   if len(scan.Skipped) != 2 || scan.Skipped[1].Item.Item_ID != 2 {
+      t.Fatal(scan.Skipped)
+   }
+}
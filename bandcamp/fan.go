@@ -0,0 +1,126 @@
+package bandcamp
+
+import (
+   "encoding/json"
+   "errors"
+   "github.com/89z/mech"
+   "io"
+   "net/http"
+   "strconv"
+   "time"
+)
+
+type Fan struct {
+   ID int
+}
+
+// New_Fan finds the fan ID from a user name, as in bandcamp.com/name.
+func New_Fan(name string) (*Fan, error) {
+   req, err := http.NewRequest("GET", "https://bandcamp.com/" + name, nil)
+   if err != nil {
+      return nil, err
+   }
+   Log.Dump(req)
+   res, err := new(http.Client).Do(req)
+   if err != nil {
+      return nil, err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
+   data, err := io.ReadAll(res.Body)
+   if err != nil {
+      return nil, err
+   }
+   var blob struct {
+      Fan_Data struct {
+         Fan_ID int
+      }
+   }
+   if err := attribute(data, "data-blob", &blob); err != nil {
+      return nil, err
+   }
+   if blob.Fan_Data.Fan_ID == 0 {
+      return nil, errors.New("fan ID is missing")
+   }
+   return &Fan{blob.Fan_Data.Fan_ID}, nil
+}
+
+// Collection returns the albums and tracks a fan bought.
+func (f Fan) Collection() *Scanner[Item] {
+   return f.collection("collection_items")
+}
+
+// Wishlist returns the albums and tracks a fan wants.
+func (f Fan) Wishlist() *Scanner[Item] {
+   return f.collection("wishlist_items")
+}
+
+func (f Fan) collection(path string) *Scanner[Item] {
+   var buf []byte
+   buf = strconv.AppendInt(buf, time.Now().Unix(), 10)
+   buf = append(buf, "::a::"...)
+   token := string(buf)
+   return &Scanner[Item]{next: func() ([]Item, bool, error) {
+      var (
+         items []Item
+         more bool
+         err error
+      )
+      items, token, more, err = f.items(path, token)
+      return items, more, err
+   }}
+}
+
+func (f Fan) items(path, token string) ([]Item, string, bool, error) {
+   body, err := mech.Encode(map[string]any{
+      "count": 100,
+      "fan_id": f.ID,
+      "older_than_token": token,
+   })
+   if err != nil {
+      return nil, "", false, err
+   }
+   req, err := http.NewRequest(
+      "POST", "https://bandcamp.com/api/fancollection/1/" + path, body,
+   )
+   if err != nil {
+      return nil, "", false, err
+   }
+   req.Header.Set("Content-Type", "application/json")
+   Log.Dump(req)
+   res, err := new(http.Transport).RoundTrip(req)
+   if err != nil {
+      return nil, "", false, err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return nil, "", false, errors.New(res.Status)
+   }
+   var coll struct {
+      Items []struct {
+         Band_ID int
+         Item_Title string
+         Tralbum_ID int
+         Tralbum_Type string
+      }
+      Last_Token string
+      More_Available bool
+   }
+   if err := json.NewDecoder(res.Body).Decode(&coll); err != nil {
+      return nil, "", false, err
+   }
+   items := make([]Item, len(coll.Items))
+   for i, each := range coll.Items {
+      items[i] = Item{
+         Band_ID: each.Band_ID, Item_ID: each.Tralbum_ID, Title: each.Item_Title,
+      }
+      if each.Tralbum_Type == "t" {
+         items[i].Item_Type = "track"
+      } else {
+         items[i].Item_Type = "album"
+      }
+   }
+   return items, coll.Last_Token, coll.More_Available, nil
+}
@@ -0,0 +1,22 @@
+package bandcamp
+
+import (
+   "fmt"
+   "testing"
+)
+
+func Test_Fan(t *testing.T) {
+   fan, err := New_Fan("bandcamp")
+   if err != nil {
+      t.Fatal(err)
+   }
+   scan := fan.Collection()
+   if scan.Scan() {
+      for _, item := range scan.Page() {
+         fmt.Printf("%+v\n", item)
+      }
+   }
+   if err := scan.Err(); err != nil {
+      t.Fatal(err)
+   }
+}
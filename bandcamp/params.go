@@ -2,10 +2,15 @@ package bandcamp
 
 import (
    "encoding/json"
+   "errors"
    "github.com/89z/format"
    "github.com/89z/format/xml"
+   "html"
    "io"
    "net/http"
+   "regexp"
+   "strconv"
+   "strings"
 )
 
 var Log format.Log
@@ -13,27 +18,54 @@ var Log format.Log
 type Params struct {
    A_ID int
    I_ID int
-   I_Type string
+   I_Type string // a, b, t
 }
 
+// also custom domains and band pages such as /music
 func New_Params(addr string) (*Params, error) {
+   if !strings.Contains(addr, "://") {
+      addr = "https://" + addr
+   }
    req, err := http.NewRequest("GET", addr, nil)
    if err != nil {
       return nil, err
    }
    Log.Dump(req)
-   res, err := new(http.Transport).RoundTrip(req)
+   // custom domains can redirect
+   res, err := new(http.Client).Do(req)
    if err != nil {
       return nil, err
    }
    defer res.Body.Close()
-   var scan xml.Scanner
-   scan.Data, err = io.ReadAll(res.Body)
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
+   data, err := io.ReadAll(res.Body)
    if err != nil {
       return nil, err
    }
+   return Parse_Params(data)
+}
+
+// Parse_Params tries each way of finding the IDs in turn.
+func Parse_Params(data []byte) (*Params, error) {
+   for _, parse := range []func([]byte) (*Params, error){
+      report_params, data_tralbum, page_properties, open_graph,
+   } {
+      param, err := parse(data)
+      if err == nil {
+         return param, nil
+      }
+   }
+   return nil, errors.New("params are missing")
+}
+
+func report_params(data []byte) (*Params, error) {
+   scan := xml.Scanner{Data: data}
    scan.Sep = []byte(`<p id="report-account-vm"`)
-   scan.Scan()
+   if !scan.Scan() {
+      return nil, errors.New("report-account-vm is missing")
+   }
    var p struct {
       Report_Params []byte `xml:"data-tou-report-params,attr"`
    }
@@ -47,7 +79,115 @@ func New_Params(addr string) (*Params, error) {
    return param, nil
 }
 
+// find an HTML attribute and decode it as JSON
+func attribute(data []byte, name string, value any) error {
+   re := regexp.MustCompile(name + `="([^"]*)"`)
+   match := re.FindSubmatch(data)
+   if match == nil {
+      return errors.New(name + " is missing")
+   }
+   return json.Unmarshal([]byte(html.UnescapeString(string(match[1]))), value)
+}
+
+// data-band is on the same script tag as data-tralbum
+func band_ID(data []byte) int {
+   var band struct {
+      ID int
+   }
+   attribute(data, "data-band", &band)
+   return band.ID
+}
+
+func data_tralbum(data []byte) (*Params, error) {
+   var tralb struct {
+      Current struct {
+         Band_ID int
+      }
+      ID int
+      Item_Type string // album, track
+   }
+   if err := attribute(data, "data-tralbum", &tralb); err != nil {
+      return nil, err
+   }
+   param := Params{A_ID: tralb.Current.Band_ID, I_ID: tralb.ID}
+   if param.A_ID == 0 {
+      param.A_ID = band_ID(data)
+   }
+   switch tralb.Item_Type {
+   case "album":
+      param.I_Type = "a"
+   case "track":
+      param.I_Type = "t"
+   default:
+      return nil, invalid_type{tralb.Item_Type}
+   }
+   return &param, nil
+}
+
+// <meta name="bc-page-properties" content="{&quot;item_type&quot;:&quot;a&quot;,
+// &quot;item_id&quot;:1670971920}">
+func page_properties(data []byte) (*Params, error) {
+   var prop struct {
+      Item_ID int
+      Item_Type string
+   }
+   if err := meta(data, "name", "bc-page-properties", &prop); err != nil {
+      return nil, err
+   }
+   param := Params{A_ID: band_ID(data), I_ID: prop.Item_ID, I_Type: prop.Item_Type}
+   if param.I_Type == "b" && param.A_ID == 0 {
+      param.A_ID = param.I_ID
+   }
+   return &param, nil
+}
+
+func meta(data []byte, attr, name string, value any) error {
+   re := regexp.MustCompile(
+      `<meta\s+` + attr + `="` + regexp.QuoteMeta(name) + `"\s+content="([^"]*)"`,
+   )
+   match := re.FindSubmatch(data)
+   if match == nil {
+      return errors.New(name + " is missing")
+   }
+   content := html.UnescapeString(string(match[1]))
+   if s, ok := value.(*string); ok {
+      *s = content
+      return nil
+   }
+   return json.Unmarshal([]byte(content), value)
+}
+
+// <meta property="og:video" content="https://bandcamp.com/EmbeddedPlayer/v=2/
+// album=1670971920/size=large/tracklist=false/artwork=small/">
+func open_graph(data []byte) (*Params, error) {
+   var video string
+   if err := meta(data, "property", "og:video", &video); err != nil {
+      return nil, err
+   }
+   param := Params{A_ID: band_ID(data)}
+   for _, field := range strings.Split(video, "/") {
+      key, val, ok := strings.Cut(field, "=")
+      if !ok {
+         continue
+      }
+      switch key {
+      case "album", "track":
+         id, err := strconv.Atoi(val)
+         if err != nil {
+            return nil, err
+         }
+         param.I_ID, param.I_Type = id, key[:1]
+         return &param, nil
+      }
+   }
+   return nil, errors.New("og:video is invalid")
+}
+
+// og:video has no band ID
 func (p Params) Band() (*Band, error) {
+   if p.A_ID == 0 {
+      return nil, errors.New("band ID is missing")
+   }
    return new_band(p.A_ID)
 }
 
@@ -23,3 +23,24 @@ func Test_Param(t *testing.T) {
       time.Sleep(99 * time.Millisecond)
    }
 }
+
+var pages = map[string]Params{
+   `<script data-tralbum="{&quot;current&quot;:{&quot;band_id&quot;:1},&quot;id&quot;:2,&quot;item_type&quot;:&quot;album&quot;}">`: {1, 2, "a"},
+   `<meta name="bc-page-properties" content="{&quot;item_type&quot;:&quot;t&quot;,&quot;item_id&quot;:3}"><script data-band="{&quot;id&quot;:4}">`: {4, 3, "t"},
+   `<meta property="og:video" content="https://bandcamp.com/EmbeddedPlayer/v=2/album=5/size=large/">`: {0, 5, "a"},
+}
+
+func Test_Parse_Params(t *testing.T) {
+   for page, want := range pages {
+      param, err := Parse_Params([]byte(page))
+      if err != nil {
+         t.Fatal(err)
+      }
+      if *param != want {
+         t.Fatal(page, param)
+      }
+   }
+   if _, err := (Params{I_ID: 5, I_Type: "a"}).Band(); err == nil {
+      t.Fatal("band ID")
+   }
+}
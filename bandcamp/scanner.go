@@ -0,0 +1,41 @@
+package bandcamp
+
+// Scanner pages through a listing, like soundcloud.Scanner.
+type Scanner[T any] struct {
+   // items passed over, so the scan could go on
+   Skipped []Item_Error
+   done bool
+   err error
+   next func() ([]T, bool, error)
+   page []T
+}
+
+func (s *Scanner[T]) Scan() bool {
+   if s.err != nil || s.done {
+      return false
+   }
+   var more bool
+   s.page, more, s.err = s.next()
+   if s.err != nil {
+      return false
+   }
+   s.done = !more
+   return true
+}
+
+func (s Scanner[T]) Page() []T {
+   return s.page
+}
+
+func (s Scanner[T]) Err() error {
+   return s.err
+}
+
+// All collects the rest of the listing.
+func (s *Scanner[T]) All() ([]T, error) {
+   var items []T
+   for s.Scan() {
+      items = append(items, s.Page()...)
+   }
+   return items, s.Err()
+}
@@ -0,0 +1,22 @@
+package bandcamp
+
+import (
+   "fmt"
+   "testing"
+)
+
+func Test_Scanner(t *testing.T) {
+   pages := [][]int{{1, 2}, {3}}
+   scan := Scanner[int]{next: func() ([]int, bool, error) {
+      page := pages[0]
+      pages = pages[1:]
+      return page, len(pages) >= 1, nil
+   }}
+   items, err := scan.All()
+   if err != nil {
+      t.Fatal(err)
+   }
+   if fmt.Sprint(items) != "[1 2 3]" {
+      t.Fatal(items)
+   }
+}
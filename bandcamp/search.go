@@ -0,0 +1,159 @@
+package bandcamp
+
+import (
+   "encoding/json"
+   "errors"
+   "github.com/89z/mech"
+   "net/http"
+)
+
+// Search filters
+const (
+   All = ""
+   Albums = "a"
+   Bands = "b" // artists and labels
+   Tracks = "t"
+)
+
+// Search returns the albums, tracks, artists and labels matching query.
+func Search(query, filter string) ([]Item, error) {
+   body, err := mech.Encode(map[string]any{
+      "fan_id": nil,
+      "full_page": false,
+      "search_filter": filter,
+      "search_text": query,
+   })
+   if err != nil {
+      return nil, err
+   }
+   req, err := http.NewRequest(
+      "POST",
+      "https://bandcamp.com/api/bcsearch_public_api/1/autocomplete_elastic",
+      body,
+   )
+   if err != nil {
+      return nil, err
+   }
+   req.Header.Set("Content-Type", "application/json")
+   Log.Dump(req)
+   res, err := new(http.Transport).RoundTrip(req)
+   if err != nil {
+      return nil, err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
+   var search struct {
+      Auto struct {
+         Results []struct {
+            Band_ID int
+            ID int
+            Is_Label bool
+            Name string
+            Type string
+         }
+      }
+   }
+   if err := json.NewDecoder(res.Body).Decode(&search); err != nil {
+      return nil, err
+   }
+   var items []Item
+   for _, result := range search.Auto.Results {
+      item := Item{Band_ID: result.Band_ID, Item_ID: result.ID, Title: result.Name}
+      switch result.Type {
+      case "a":
+         item.Item_Type = "album"
+      case "t":
+         item.Item_Type = "track"
+      case "b":
+         item.Band_ID = result.ID
+         if result.Is_Label {
+            item.Item_Type = "label"
+         } else {
+            item.Item_Type = "band"
+         }
+      default:
+         // fans
+         continue
+      }
+      items = append(items, item)
+   }
+   return items, nil
+}
+
+// Dig browses releases by tag, like the tag pages.
+type Dig struct {
+   Tags []string
+   // pop, new, rec
+   Sort string
+   // all, digital, vinyl, cd, cassette
+   Format string
+}
+
+func (d Dig) Scanner() *Scanner[Item] {
+   var page int
+   return &Scanner[Item]{next: func() ([]Item, bool, error) {
+      page++
+      return d.page(page)
+   }}
+}
+
+func (d Dig) page(page int) ([]Item, bool, error) {
+   if d.Sort == "" {
+      d.Sort = "pop"
+   }
+   if d.Format == "" {
+      d.Format = "all"
+   }
+   body, err := mech.Encode(map[string]any{
+      "filters": map[string]any{
+         "format": d.Format,
+         "location": 0,
+         "sort": d.Sort,
+         "tags": d.Tags,
+      },
+      "page": page,
+   })
+   if err != nil {
+      return nil, false, err
+   }
+   req, err := http.NewRequest(
+      "POST", "https://bandcamp.com/api/hub/2/dig_deeper", body,
+   )
+   if err != nil {
+      return nil, false, err
+   }
+   req.Header.Set("Content-Type", "application/json")
+   Log.Dump(req)
+   res, err := new(http.Transport).RoundTrip(req)
+   if err != nil {
+      return nil, false, err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return nil, false, errors.New(res.Status)
+   }
+   var dig struct {
+      Items []struct {
+         Band_ID int
+         Title string
+         Tralbum_ID int
+         Tralbum_Type string
+      }
+      More_Available bool
+   }
+   if err := json.NewDecoder(res.Body).Decode(&dig); err != nil {
+      return nil, false, err
+   }
+   items := make([]Item, len(dig.Items))
+   for i, each := range dig.Items {
+      items[i] = Item{Band_ID: each.Band_ID, Item_ID: each.Tralbum_ID, Title: each.Title}
+      if each.Tralbum_Type == "t" {
+         items[i].Item_Type = "track"
+      } else {
+         items[i].Item_Type = "album"
+      }
+   }
+   return items, dig.More_Available, nil
+}
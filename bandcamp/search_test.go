@@ -0,0 +1,24 @@
+package bandcamp
+
+import (
+   "fmt"
+   "testing"
+)
+
+func Test_Search(t *testing.T) {
+   items, err := Search("schnauss", All)
+   if err != nil {
+      t.Fatal(err)
+   }
+   for _, item := range items {
+      fmt.Printf("%+v\n", item)
+   }
+}
+
+func Test_Dig(t *testing.T) {
+   scan := Dig{Tags: []string{"ambient"}}.Scanner()
+   if !scan.Scan() {
+      t.Fatal(scan.Err())
+   }
+   fmt.Println(len(scan.Page()))
+}
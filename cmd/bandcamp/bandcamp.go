@@ -0,0 +1,136 @@
+package main
+
+import (
+   "errors"
+   "fmt"
+   "github.com/89z/mech"
+   "github.com/89z/mech/bandcamp"
+   "io"
+   "net/http"
+   "os"
+   "path/filepath"
+   "strconv"
+   "time"
+)
+
+type flags struct {
+   address string
+   cover int
+   discography bool
+   info bool
+   output string
+   sleep time.Duration
+}
+
+func (f flags) do() error {
+   param, err := bandcamp.New_Params(f.address)
+   if err != nil {
+      return err
+   }
+   // band pages such as /music have no album or track
+   if !f.discography && param.I_Type != "b" {
+      tralb, err := param.Tralbum()
+      if err != nil {
+         return err
+      }
+      return f.tralbum(tralb)
+   }
+   band, err := param.Band()
+   if err != nil {
+      return err
+   }
+   if f.info {
+      fmt.Println("Band:", band.Name)
+      fmt.Println("Location:", band.Location)
+      fmt.Println("Bio:", band.Bio)
+   }
+   scan := band.Scanner(f.sleep)
+   for scan.Scan() {
+      for _, tralb := range scan.Page() {
+         if err := f.tralbum(&tralb); err != nil {
+            return err
+         }
+      }
+   }
+   if err := scan.Err(); err != nil {
+      return err
+   }
+   for _, skip := range scan.Skipped {
+      fmt.Println("skipped:", skip)
+   }
+   if len(scan.Skipped) >= 1 {
+      return errors.New(strconv.Itoa(len(scan.Skipped)) + " releases failed")
+   }
+   return nil
+}
+
+// one folder for each album or single
+func (f flags) tralbum(tralb *bandcamp.Tralbum) error {
+   if f.info {
+      fmt.Println("Title:", tralb.Title)
+      fmt.Println("Artist:", tralb.Tralbum_Artist)
+      if tralb.Label != "" {
+         fmt.Println("Label:", tralb.Label)
+      }
+      for _, tag := range tralb.Tags {
+         fmt.Println("Tag:", tag.Name)
+      }
+      for _, track := range tralb.Tracks {
+         fmt.Println(track)
+      }
+      return nil
+   }
+   dir := filepath.Join(f.output, mech.Clean(tralb.Tralbum_Artist + "-" + tralb.Title))
+   if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+      return err
+   }
+   if f.cover >= 1 {
+      img, err := bandcamp.Select_Image(f.cover, bandcamp.JPEG, false)
+      if err != nil {
+         return err
+      }
+      name, err := tralb.Cover(filepath.Join(dir, "cover"), *img)
+      if err != nil {
+         return err
+      }
+      fmt.Println(name)
+   }
+   for _, track := range tralb.Tracks {
+      if track.Streaming_URL == nil {
+         fmt.Println("not streamable:", track.Title)
+         continue
+      }
+      name := fmt.Sprintf("%02d-%v.mp3", track.Track_Num, mech.Clean(track.Title))
+      name = filepath.Join(dir, name)
+      if _, err := os.Stat(name); err == nil {
+         fmt.Println("exists:", name)
+         continue
+      }
+      if err := download(track.Streaming_URL.MP3_128, name); err != nil {
+         return err
+      }
+      time.Sleep(f.sleep)
+   }
+   return nil
+}
+
+func download(addr, name string) error {
+   fmt.Println("GET", addr)
+   res, err := http.Get(addr)
+   if err != nil {
+      return err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return errors.New(res.Status)
+   }
+   file, err := os.Create(name)
+   if err != nil {
+      return err
+   }
+   defer file.Close()
+   if _, err := io.Copy(file, res.Body); err != nil {
+      return err
+   }
+   return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+   "flag"
+   "github.com/89z/mech/bandcamp"
+   "time"
+)
+
+func main() {
+   var flags flags
+   // a
+   flag.StringVar(&flags.address, "a", "", "address")
+   // c
+   flag.IntVar(&flags.cover, "c", 0, "cover art width, 0 for none")
+   // d
+   flag.BoolVar(&flags.discography, "d", false, "entire discography")
+   // i
+   flag.BoolVar(&flags.info, "i", false, "information")
+   // o
+   flag.StringVar(&flags.output, "o", ".", "output folder")
+   // s
+   flag.DurationVar(&flags.sleep, "s", time.Second, "sleep between requests")
+   // v
+   var verbose bool
+   flag.BoolVar(&verbose, "v", false, "verbose")
+   flag.Parse()
+   if verbose {
+      bandcamp.Log.Level = 1
+   }
+   if flags.address != "" {
+      err := flags.do()
+      if err != nil {
+         panic(err)
+      }
+   } else {
+      flag.Usage()
+   }
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+   "errors"
+   "fmt"
+   "github.com/89z/format/hls"
+   "github.com/89z/mech"
+   "github.com/89z/mech/youtube"
+   "net/http"
+   "os"
+   "strconv"
+)
+
+func (v video) HLS(play *youtube.Player) error {
+   addr := play.StreamingData.HlsManifestUrl
+   fmt.Println("GET", addr)
+   res, err := http.Get(addr)
+   if err != nil {
+      return err
+   }
+   defer res.Body.Close()
+   master, err := hls.New_Scanner(res.Body).Master()
+   if err != nil {
+      return err
+   }
+   sel, err := mech.New_Selector(v.video)
+   if err != nil {
+      return err
+   }
+   streams := make([]stream, len(master.Streams))
+   for i, each := range master.Streams {
+      streams[i].Stream = each
+   }
+   str, ok := mech.Choose(*sel, streams)
+   if !ok {
+      return errors.New("no HLS stream matches " + strconv.Quote(v.video))
+   }
+   ref, err := res.Request.URL.Parse(str.Raw_URI)
+   if err != nil {
+      return err
+   }
+   file, err := os.Create(play.Base() + hls.TS)
+   if err != nil {
+      return err
+   }
+   defer file.Close()
+   live := youtube.Live{
+      Address: ref.String(),
+      Duration: v.duration,
+      From_Start: v.start,
+   }
+   if err := live.Encode(file); err != nil {
+      return err
+   }
+   if len(live.Gaps) >= 1 {
+      fmt.Println("missing segments", live.Gaps)
+   }
+   return nil
+}
+
+type stream struct {
+   hls.Stream
+}
+
+func (s stream) Value(key string) string {
+   var width, height int64
+   // 1280x720
+   fmt.Sscanf(s.Resolution, "%dx%d", &width, &height)
+   switch key {
+   case "bitrate":
+      return strconv.FormatInt(s.Bandwidth, 10)
+   case "codec":
+      return s.Raw_Codecs
+   case "height":
+      if height >= 1 {
+         return strconv.FormatInt(height, 10)
+      }
+   case "width":
+      if width >= 1 {
+         return strconv.FormatInt(width, 10)
+      }
+   }
+   return ""
+}
@@ -3,10 +3,16 @@ package main
 import (
    "flag"
    "github.com/89z/mech/youtube"
+   "os"
+   "path/filepath"
    "strings"
 )
 
 func main() {
+   home, err := os.UserHomeDir()
+   if err != nil {
+      panic(err)
+   }
    var vid video
    // a
    flag.StringVar(&vid.address, "a", "", "address")
@@ -15,22 +21,49 @@ func main() {
    flag.BoolVar(&access, "access", false, "create OAuth access token")
    // b
    flag.StringVar(&vid.id, "b", "", "video ID")
+   // comments
+   flag.BoolVar(&vid.comments, "comments", false, "export comments as JSON")
    // f
-   flag.IntVar(&vid.height, "f", 720, "target video height")
+   var buf strings.Builder
+   buf.WriteString("video selection, for example\n")
+   buf.WriteString("height<=1080,codec=vp9|avc1,fps>=50,prefer=hdr")
+   flag.StringVar(&vid.video, "f", "height<=720", buf.String())
    // g
-   flag.StringVar(&vid.audio, "g", "AUDIO_QUALITY_MEDIUM", "target audio")
+   flag.StringVar(
+      &vid.audio, "g", "quality=AUDIO_QUALITY_MEDIUM", "audio selection",
+   )
+   // gl
+   flag.StringVar(&vid.region, "gl", "", "region, for example US")
+   // hl
+   flag.StringVar(&vid.language, "hl", "", "language, for example en")
    // i
    flag.BoolVar(&vid.info, "i", false, "information")
+   // m
+   flag.BoolVar(&vid.muxed, "m", false, "muxed audio and video")
+   // merge
+   flag.BoolVar(&vid.merge, "merge", false, "merge audio and video into MP4")
+   // music
+   flag.BoolVar(&vid.music, "music", false, "YouTube Music audio with tags")
+   // p
+   vid.profiles = filepath.Join(home, "mech/youtube-profiles.json")
+   flag.StringVar(&vid.profiles, "p", vid.profiles, "client profiles")
    // r
-   var buf strings.Builder
-   buf.WriteString("0: Android\n")
-   buf.WriteString("1: Android embed\n")
-   buf.WriteString("2: Android racy\n")
-   buf.WriteString("3: Android content")
-   flag.IntVar(&vid.request, "r", 0, buf.String())
+   buf.Reset()
+   buf.WriteString("client profile, empty to try each in order")
+   for _, pro := range youtube.Profiles {
+      buf.WriteString("\n")
+      buf.WriteString(pro.Name)
+   }
+   flag.StringVar(&vid.request, "r", "", buf.String())
    // refresh
    var refresh bool
    flag.BoolVar(&refresh, "refresh", false, "create OAuth refresh token")
+   // start
+   flag.BoolVar(&vid.start, "start", false, "record live from start of DVR window")
+   // t
+   flag.DurationVar(&vid.duration, "t", 0, "live duration limit, for example 1h")
+   // thumb
+   flag.BoolVar(&vid.thumb, "thumb", false, "save thumbnail")
    // v
    var verbose bool
    flag.BoolVar(&verbose, "v", false, "verbose")
@@ -38,6 +71,9 @@ func main() {
    if verbose {
       youtube.Log.Level = 1
    }
+   if strings.Contains(vid.address, "music.youtube.com") {
+      vid.music = true
+   }
    if refresh {
       err := do_refresh()
       if err != nil {
@@ -1,23 +1,32 @@
 package main
 
 import (
+   "bytes"
+   "errors"
    "fmt"
    "github.com/89z/mech"
    "github.com/89z/mech/youtube"
+   "net/http"
    "os"
+   "strconv"
+   "time"
 )
 
-func download(form *youtube.Format, base string) error {
+func download(form *youtube.Format, base string) (string, error) {
    ext, err := mech.Extension_By_Type(form.MimeType)
    if err != nil {
-      return err
+      return "", err
    }
    file, err := os.Create(base + ext)
    if err != nil {
-      return err
+      return "", err
    }
-   defer file.Close()
-   return form.Encode(file)
+   if err := form.Encode(file); err != nil {
+      file.Close()
+      os.Remove(file.Name())
+      return "", err
+   }
+   return file.Name(), file.Close()
 }
 
 func (v video) player() (*youtube.Player, error) {
@@ -28,63 +37,288 @@ func (v video) player() (*youtube.Player, error) {
          return nil, err
       }
    }
-   if v.request == 1 {
-      return youtube.Android_Embed.Player(v.id)
-   }
-   if v.request >= 2 {
-      home, err := os.UserHomeDir()
+   pros := youtube.Profiles
+   if _, err := os.Stat(v.profiles); err == nil {
+      pros, err = youtube.Open_Profiles(v.profiles)
       if err != nil {
          return nil, err
       }
-      change, err := youtube.Open_Exchange(home + "/mech/youtube.json")
+   }
+   home, err := os.UserHomeDir()
+   if err != nil {
+      return nil, err
+   }
+   change, err := youtube.Open_Exchange(home + "/mech/youtube.json")
+   if err != nil && !errors.Is(err, os.ErrNotExist) {
+      return nil, err
+   }
+   var opts []youtube.Option
+   if v.language != "" {
+      opts = append(opts, youtube.Language(v.language))
+   }
+   if v.region != "" {
+      opts = append(opts, youtube.Region(v.region))
+   }
+   if v.request != "" {
+      pro, err := youtube.Find_Profile(pros, v.request)
       if err != nil {
          return nil, err
       }
-      if v.request == 2 {
-         return youtube.Android_Racy.Exchange(v.id, change)
+      pros = []youtube.Profile{*pro}
+   }
+   if change != nil && change.Expired() && uses_OAuth(pros) {
+      err := change.Refresh()
+      if err == nil {
+         err = change.Create(home + "/mech/youtube.json")
+      }
+      if err != nil {
+         // the profiles without OAuth can still work
+         fmt.Println("OAuth refresh:", err)
+         change = nil
       }
-      return youtube.Android_Content.Exchange(v.id, change)
    }
-   return youtube.Android.Player(v.id)
+   return youtube.Player_Auto(pros, v.id, change, opts...)
+}
+
+func uses_OAuth(pros []youtube.Profile) bool {
+   for _, pro := range pros {
+      if pro.OAuth {
+         return true
+      }
+   }
+   return false
 }
 
 type video struct {
    address string
    audio string
-   height int
+   comments bool
+   duration time.Duration
    id string
    info bool
-   request int
+   language string
+   merge bool
+   music bool
+   muxed bool
+   profiles string
+   region string
+   request string
+   start bool
+   thumb bool
+   video string
 }
+
 func (v video) do() error {
    play, err := v.player()
    if err != nil {
       return err
    }
    forms := play.StreamingData.AdaptiveFormats
-   if v.info {
+   if v.thumb && !v.info {
+      err := thumbnail(play)
+      if err != nil {
+         return err
+      }
+   }
+   if v.comments {
+      return comments(play)
+   } else if v.music && !v.info {
+      fmt.Println(play.PlayabilityStatus)
+      return v.do_music(play)
+   } else if v.info {
       forms.Media_Type()
+      play.StreamingData.Formats.Media_Type()
       fmt.Println(play)
+   } else if play.VideoDetails.IsLive {
+      fmt.Println(play.PlayabilityStatus)
+      if play.StreamingData.HlsManifestUrl == "" {
+         return errors.New("HLS manifest is missing")
+      }
+      return v.HLS(play)
+   } else if v.muxed {
+      fmt.Println(play.PlayabilityStatus)
+      sel, err := mech.New_Selector(v.video)
+      if err != nil {
+         return err
+      }
+      form, ok := play.StreamingData.Formats.Select(*sel)
+      if !ok {
+         return errors.New("no muxed format matches " + strconv.Quote(v.video))
+      }
+      _, err = download(form, play.Base())
+      return err
    } else {
       fmt.Println(play.PlayabilityStatus)
-      if v.audio != "" {
-         form, ok := forms.Audio(v.audio)
-         if ok {
-            err := download(form, play.Base())
-            if err != nil {
-               return err
-            }
+      return v.adaptive(play)
+   }
+   return nil
+}
+
+func (v video) adaptive(play *youtube.Player) error {
+   var (
+      audio, video string
+      forms = play.StreamingData.AdaptiveFormats
+      suffix = ","
+   )
+   if v.merge {
+      // only MP4 can be merged
+      suffix = "/mp4,"
+   }
+   if v.audio != "" {
+      sel, err := mech.New_Selector("type=audio" + suffix + v.audio)
+      if err != nil {
+         return err
+      }
+      form, ok := forms.Select(*sel)
+      if ok {
+         audio, err = download(form, play.Base())
+         if err != nil {
+            return err
          }
       }
-      if v.height >= 1 {
-         form, ok := forms.Video(v.height)
-         if ok {
-            err := download(form, play.Base())
-            if err != nil {
-               return err
-            }
+   }
+   if v.video != "" {
+      sel, err := mech.New_Selector("type=video" + suffix + v.video)
+      if err != nil {
+         return err
+      }
+      form, ok := forms.Select(*sel)
+      if ok {
+         video, err = download(form, play.Base())
+         if err != nil {
+            return err
+         }
+      }
+   }
+   if v.merge && audio != "" && video != "" {
+      return merge(video, audio, play.Base())
+   }
+   return nil
+}
+
+func (v video) do_music(play *youtube.Player) error {
+   track, err := youtube.Music.Music_Track(play.VideoDetails.VideoId)
+   if err != nil {
+      return err
+   }
+   fmt.Printf("%+v\n", *track)
+   // only MP4 can be tagged
+   sel, err := mech.New_Selector("type=audio/mp4," + v.audio)
+   if err != nil {
+      return err
+   }
+   form, ok := play.StreamingData.AdaptiveFormats.Select(*sel)
+   if !ok {
+      return errors.New("no audio format matches " + strconv.Quote(v.audio))
+   }
+   name, err := download(form, track.Base())
+   if err != nil {
+      return err
+   }
+   file, err := os.Open(name)
+   if err != nil {
+      return err
+   }
+   defer file.Close()
+   buf := new(bytes.Buffer)
+   if err := track.Metadata().Tag_MP4(buf, file); err != nil {
+      return err
+   }
+   if err := file.Close(); err != nil {
+      return err
+   }
+   return os.WriteFile(name, buf.Bytes(), os.ModePerm)
+}
+
+func merge(video, audio, base string) error {
+   vid, err := os.Open(video)
+   if err != nil {
+      return err
+   }
+   defer vid.Close()
+   aud, err := os.Open(audio)
+   if err != nil {
+      return err
+   }
+   defer aud.Close()
+   file, err := os.Create(base + ".mp4")
+   if err != nil {
+      return err
+   }
+   defer file.Close()
+   if err := mech.Merge(file, vid, aud); err != nil {
+      return err
+   }
+   if err := vid.Close(); err != nil {
+      return err
+   }
+   if err := aud.Close(); err != nil {
+      return err
+   }
+   if err := os.Remove(video); err != nil {
+      return err
+   }
+   return os.Remove(audio)
+}
+
+func thumbnail(play *youtube.Player) error {
+   thumb, err := play.Thumbnail()
+   if err != nil {
+      return err
+   }
+   ext, err := thumb.Ext()
+   if err != nil {
+      return err
+   }
+   fmt.Println("GET", thumb.URL)
+   res, err := http.Get(thumb.URL)
+   if err != nil {
+      return err
+   }
+   defer res.Body.Close()
+   file, err := os.Create(play.Base() + ext)
+   if err != nil {
+      return err
+   }
+   defer file.Close()
+   if _, err := file.ReadFrom(res.Body); err != nil {
+      return err
+   }
+   return nil
+}
+
+func comments(play *youtube.Player) error {
+   coms, err := youtube.Web.Comments(play.VideoDetails.VideoId)
+   if err != nil {
+      return err
+   }
+   var items []youtube.Comment
+   for {
+      for _, com := range coms.Items {
+         if err := youtube.Web.Replies(&com); err != nil {
+            return err
          }
+         items = append(items, com)
+      }
+      if coms.Continuation == "" {
+         break
       }
+      coms, err = youtube.Web.Comments_Continue(coms.Continuation)
+      if err != nil {
+         return err
+      }
+   }
+   buf, err := mech.Encode(items)
+   if err != nil {
+      return err
+   }
+   file, err := os.Create(play.Base() + ".json")
+   if err != nil {
+      return err
+   }
+   defer file.Close()
+   if _, err := buf.WriteTo(file); err != nil {
+      return err
    }
    return nil
 }
@@ -95,8 +329,7 @@ func do_refresh() error {
       return err
    }
    fmt.Println(auth)
-   fmt.Scanln()
-   change, err := auth.Exchange()
+   change, err := auth.Poll()
    if err != nil {
       return err
    }
@@ -5,9 +5,7 @@ go 1.18
 require (
 	github.com/89z/format v1.39.6
 	github.com/chmike/cmac-go v1.1.0
+	github.com/edgeware/mp4ff v0.29.0
 )
 
-require (
-	github.com/edgeware/mp4ff v0.29.0 // indirect
-	google.golang.org/protobuf v1.28.0 // indirect
-)
+require google.golang.org/protobuf v1.28.0 // indirect
@@ -41,6 +41,8 @@ func Extension_By_Type(typ string) (string, error) {
       return ".mp3", nil
    case "audio/mp4":
       return ".m4a", nil
+   case "audio/ogg":
+      return ".ogg", nil
    case "audio/webm":
       return ".weba", nil
    case "video/mp4":
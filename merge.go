@@ -0,0 +1,98 @@
+package mech
+
+import (
+   "errors"
+   "github.com/edgeware/mp4ff/mp4"
+   "io"
+)
+
+// Merge combines a fragmented MP4 video file and a fragmented MP4 audio file
+// into one fragmented MP4 file. Both inputs are read into memory.
+func Merge(dst io.Writer, video, audio io.Reader) error {
+   vid, err := new_track(video, 1)
+   if err != nil {
+      return err
+   }
+   aud, err := new_track(audio, 2)
+   if err != nil {
+      return err
+   }
+   moov := vid.Init.Moov
+   moov.AddChild(aud.Init.Moov.Trak)
+   moov.Mvex.AddChild(aud.Init.Moov.Mvex.Trex)
+   moov.Mvhd.NextTrackID = 3
+   if err := vid.Init.Encode(dst); err != nil {
+      return err
+   }
+   var seq uint32
+   for len(vid.frags) >= 1 || len(aud.frags) >= 1 {
+      var frag *mp4.Fragment
+      if len(aud.frags) == 0 {
+         frag = vid.next()
+      } else if len(vid.frags) == 0 {
+         frag = aud.next()
+      } else if vid.time() <= aud.time() {
+         frag = vid.next()
+      } else {
+         frag = aud.next()
+      }
+      seq++
+      frag.Moof.Mfhd.SequenceNumber = seq
+      if err := frag.Encode(dst); err != nil {
+         return err
+      }
+   }
+   return nil
+}
+
+type track struct {
+   *mp4.File
+   frags []*mp4.Fragment
+   scale float64
+}
+
+func new_track(r io.Reader, id uint32) (*track, error) {
+   file, err := mp4.DecodeFile(r)
+   if err != nil {
+      return nil, err
+   }
+   if file.Init == nil || file.Init.Moov.Mvex == nil {
+      return nil, errors.New("file is not fragmented")
+   }
+   if len(file.Init.Moov.Traks) != 1 {
+      return nil, errors.New("file has more than one track")
+   }
+   trak := file.Init.Moov.Trak
+   trak.Tkhd.TrackID = id
+   file.Init.Moov.Mvex.Trex.TrackID = id
+   tra := track{File: file, scale: float64(trak.Mdia.Mdhd.Timescale)}
+   for _, seg := range file.Segments {
+      for _, frag := range seg.Fragments {
+         for _, traf := range frag.Moof.Trafs {
+            traf.Tfhd.TrackID = id
+            // offsets are relative to the old file, so use the moof instead
+            if traf.Tfhd.HasBaseDataOffset() {
+               traf.Tfhd.Flags &^= 0x000001
+               traf.Tfhd.Flags |= 0x020000
+            }
+         }
+         tra.frags = append(tra.frags, frag)
+      }
+   }
+   return &tra, nil
+}
+
+func (t *track) next() *mp4.Fragment {
+   frag := t.frags[0]
+   t.frags = t.frags[1:]
+   return frag
+}
+
+// decode time in seconds of the next fragment
+func (t track) time() float64 {
+   tfdt := t.frags[0].Moof.Traf.Tfdt
+   if tfdt == nil || t.scale == 0 {
+      return 0
+   }
+   return float64(tfdt.BaseMediaDecodeTime) / t.scale
+}
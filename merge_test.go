@@ -0,0 +1,98 @@
+package mech
+
+import (
+   "bytes"
+   "fmt"
+   "github.com/edgeware/mp4ff/mp4"
+   "testing"
+)
+
+// samples are the first three bytes of media, then the fragment number. The
+// sample offsets are absolute, like some muxers write.
+func new_fragmented(media string, scale, dur uint32) (*bytes.Buffer, error) {
+   init := mp4.CreateEmptyInit()
+   init.AddEmptyTrack(scale, media, "und")
+   buf := new(bytes.Buffer)
+   if err := init.Encode(buf); err != nil {
+      return nil, err
+   }
+   for i := uint32(0); i < 3; i++ {
+      frag, err := mp4.CreateFragment(i+1, 1)
+      if err != nil {
+         return nil, err
+      }
+      frag.AddFullSample(mp4.FullSample{
+         Sample: mp4.NewSample(mp4.SyncSampleFlags, dur, 4, 0),
+         DecodeTime: uint64(i * dur),
+         Data: []byte(fmt.Sprint(media[:3], i)),
+      })
+      tfhd := frag.Moof.Traf.Tfhd
+      tfhd.Flags &^= 0x020000
+      tfhd.Flags |= 0x000001
+      tfhd.BaseDataOffset = uint64(buf.Len())
+      if err := frag.Encode(buf); err != nil {
+         return nil, err
+      }
+   }
+   return buf, nil
+}
+
+func Test_Merge(t *testing.T) {
+   video, err := new_fragmented("video", 90000, 90000)
+   if err != nil {
+      t.Fatal(err)
+   }
+   audio, err := new_fragmented("audio", 48000, 48000)
+   if err != nil {
+      t.Fatal(err)
+   }
+   buf := new(bytes.Buffer)
+   if err := Merge(buf, video, audio); err != nil {
+      t.Fatal(err)
+   }
+   file, err := mp4.DecodeFile(buf)
+   if err != nil {
+      t.Fatal(err)
+   }
+   if len(file.Init.Moov.Traks) != 2 {
+      t.Fatal(file.Init.Moov.Traks)
+   }
+   var (
+      ids []uint32
+      data []string
+      seq uint32
+   )
+   for _, seg := range file.Segments {
+      for _, frag := range seg.Fragments {
+         seq++
+         if frag.Moof.Mfhd.SequenceNumber != seq {
+            t.Fatal(frag.Moof.Mfhd)
+         }
+         id := frag.Moof.Traf.Tfhd.TrackID
+         ids = append(ids, id)
+         // GetTrex reports ok backwards
+         var trex *mp4.TrexBox
+         for _, each := range file.Init.Moov.Mvex.Trexs {
+            if each.TrackID == id {
+               trex = each
+            }
+         }
+         if trex == nil {
+            t.Fatal(id)
+         }
+         samples, err := frag.GetFullSamples(trex)
+         if err != nil {
+            t.Fatal(err)
+         }
+         for _, sample := range samples {
+            data = append(data, string(sample.Data))
+         }
+      }
+   }
+   if fmt.Sprint(ids) != "[1 2 1 2 1 2]" {
+      t.Fatal(ids)
+   }
+   if fmt.Sprint(data) != "[vid0 aud0 vid1 aud1 vid2 aud2]" {
+      t.Fatal(data)
+   }
+}
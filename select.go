@@ -0,0 +1,200 @@
+package mech
+
+import (
+   "strconv"
+   "strings"
+)
+
+// Value returns "" if the key is missing
+type Rendition interface {
+   Value(string) string
+}
+
+type Term struct {
+   Key string
+   Operator string
+   Values []string
+}
+
+// height<=1080,codec=vp9|avc1,fps>=50,prefer=hdr
+type Selector struct {
+   Prefer []string
+   Terms []Term
+}
+
+var operators = []string{"<=", ">=", "!=", "=", "<", ">"}
+
+func New_Selector(raw string) (*Selector, error) {
+   var sel Selector
+   for _, field := range strings.Split(raw, ",") {
+      field = strings.TrimSpace(field)
+      if field == "" {
+         continue
+      }
+      var term Term
+      for _, op := range operators {
+         key, val, found := strings.Cut(field, op)
+         if found {
+            term.Key = strings.TrimSpace(key)
+            term.Operator = op
+            term.Values = strings.Split(val, "|")
+            break
+         }
+      }
+      if term.Key == "" {
+         return nil, invalid_term{field}
+      }
+      if term.Key == "prefer" {
+         if term.Operator != "=" {
+            return nil, invalid_term{field}
+         }
+         sel.Prefer = append(sel.Prefer, term.Values...)
+      } else {
+         sel.Terms = append(sel.Terms, term)
+      }
+   }
+   return &sel, nil
+}
+
+func (s Selector) Match(r Rendition) bool {
+   for _, term := range s.Terms {
+      if !term.Match(r.Value(term.Key)) {
+         return false
+      }
+   }
+   return true
+}
+
+// codec=avc1 matches avc1.640028
+func (t Term) Match(value string) bool {
+   if value == "" {
+      return false
+   }
+   if t.Operator == "!=" {
+      for _, want := range t.Values {
+         if compare(value, want, true) == 0 {
+            return false
+         }
+      }
+      return true
+   }
+   for _, want := range t.Values {
+      diff := compare(value, want, t.Operator == "=")
+      switch t.Operator {
+      case "=":
+         if diff == 0 {
+            return true
+         }
+      case "<":
+         if diff < 0 {
+            return true
+         }
+      case "<=":
+         if diff <= 0 {
+            return true
+         }
+      case ">":
+         if diff > 0 {
+            return true
+         }
+      case ">=":
+         if diff >= 0 {
+            return true
+         }
+      }
+   }
+   return false
+}
+
+// rank by Prefer keys, then closeness to each bound, then bitrate, then size
+func Choose[T Rendition](s Selector, items []T) (*T, bool) {
+   var out *T
+   for i, item := range items {
+      if s.Match(item) {
+         if out == nil || s.less(*out, item) {
+            out = &items[i]
+         }
+      }
+   }
+   return out, out != nil
+}
+
+func (s Selector) less(a, b Rendition) bool {
+   if s.prefer(a) != s.prefer(b) {
+      return s.prefer(a) < s.prefer(b)
+   }
+   for _, term := range s.Terms {
+      var sign float64
+      switch term.Operator {
+      case "<", "<=":
+         sign = 1
+      case ">", ">=":
+         sign = -1
+      default:
+         continue
+      }
+      x, y := number(a.Value(term.Key)), number(b.Value(term.Key))
+      if x != y {
+         return sign * x < sign * y
+      }
+   }
+   for _, key := range []string{"bitrate", "size"} {
+      x, y := number(a.Value(key)), number(b.Value(key))
+      if x != y {
+         return x < y
+      }
+   }
+   return false
+}
+
+func (s Selector) prefer(r Rendition) int {
+   var count int
+   for _, key := range s.Prefer {
+      switch r.Value(key) {
+      case "", "0", "false":
+      default:
+         count++
+      }
+   }
+   return count
+}
+
+func compare(value, want string, prefix bool) int {
+   x, err := strconv.ParseFloat(value, 64)
+   if err == nil {
+      y, err := strconv.ParseFloat(want, 64)
+      if err == nil {
+         switch {
+         case x < y:
+            return -1
+         case x > y:
+            return 1
+         }
+         return 0
+      }
+   }
+   value, want = strings.ToLower(value), strings.ToLower(want)
+   if prefix && strings.HasPrefix(value, want) {
+      return 0
+   }
+   return strings.Compare(value, want)
+}
+
+func number(value string) float64 {
+   num, err := strconv.ParseFloat(value, 64)
+   if err != nil {
+      return 0
+   }
+   return num
+}
+
+type invalid_term struct {
+   value string
+}
+
+func (i invalid_term) Error() string {
+   var buf []byte
+   buf = append(buf, "invalid term "...)
+   buf = strconv.AppendQuote(buf, i.value)
+   return string(buf)
+}
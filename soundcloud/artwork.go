@@ -0,0 +1,77 @@
+package soundcloud
+
+import (
+   "errors"
+   "net/http"
+   "os"
+   "path"
+   "path/filepath"
+   "strconv"
+   "strings"
+)
+
+// the original can be missing or PNG
+func (t Track) Artworks(size string) ([]string, error) {
+   if size != "original" && size != "large" {
+      var ok bool
+      for _, img := range Images {
+         if img.Size == size {
+            ok = true
+         }
+      }
+      if !ok {
+         return nil, errors.New("invalid size " + strconv.Quote(size))
+      }
+   }
+   addrs := []string{t.Artwork_Size(size)}
+   if size == "original" {
+      orig := addrs[0]
+      addrs = append(addrs, strings.TrimSuffix(orig, path.Ext(orig)) + ".png")
+   }
+   for _, fall := range []string{"t500x500", "large"} {
+      if fall != size {
+         addrs = append(addrs, t.Artwork_Size(fall))
+      }
+   }
+   return addrs, nil
+}
+
+// Save_Artwork saves the artwork next to the audio file, and returns its name.
+func (t Track) Save_Artwork(audio, size string) (string, error) {
+   addrs, err := t.Artworks(size)
+   if err != nil {
+      return "", err
+   }
+   base := strings.TrimSuffix(audio, filepath.Ext(audio))
+   for _, addr := range addrs {
+      req, err := http.NewRequest("GET", addr, nil)
+      if err != nil {
+         return "", err
+      }
+      Log.Dump(req)
+      res, err := new(http.Transport).RoundTrip(req)
+      if err != nil {
+         return "", err
+      }
+      if res.StatusCode != http.StatusOK {
+         if err := res.Body.Close(); err != nil {
+            return "", err
+         }
+         continue
+      }
+      name := base + path.Ext(req.URL.Path)
+      file, err := os.Create(name)
+      if err != nil {
+         res.Body.Close()
+         return "", err
+      }
+      _, err = file.ReadFrom(res.Body)
+      res.Body.Close()
+      if err != nil {
+         file.Close()
+         return "", err
+      }
+      return name, file.Close()
+   }
+   return "", errors.New("artwork is missing")
+}
@@ -0,0 +1,185 @@
+package soundcloud
+
+import (
+   "errors"
+   "io"
+   "net/http"
+   "os"
+   "path/filepath"
+   "regexp"
+   "strings"
+   "sync"
+)
+
+// replaced by Refresh_Client_ID when the API rejects it
+var Client_ID = "iZIs9mchVcX5lhVRyQGGAYlNPVldzAoX"
+
+var (
+   // guards Client_ID and client_refreshed
+   client_mu sync.Mutex
+   // the API only gets one refresh per process
+   client_refreshed bool
+)
+
+// file holding the last client ID found
+var Cache = default_cache()
+
+func default_cache() string {
+   home, err := os.UserHomeDir()
+   if err != nil {
+      return ""
+   }
+   return filepath.Join(home, "mech", "soundcloud.txt")
+}
+
+var (
+   cache_once sync.Once
+   cache_err error
+)
+
+// Open_Cache sets Cache, and reads Client_ID from it if the file exists.
+func Open_Cache(name string) error {
+   Cache = name
+   buf, err := os.ReadFile(name)
+   if errors.Is(err, os.ErrNotExist) {
+      return nil
+   }
+   if err != nil {
+      return err
+   }
+   if id := strings.TrimSpace(string(buf)); id != "" {
+      client_mu.Lock()
+      Client_ID = id
+      client_mu.Unlock()
+   }
+   return nil
+}
+
+var (
+   // <script crossorigin src="https://a-v2.sndcdn.com/assets/0-b5a2d2a2.js">
+   script_src = regexp.MustCompile(`<script crossorigin src="([^"]+)"`)
+   // client_id:"iZIs9mchVcX5lhVRyQGGAYlNPVldzAoX"
+   script_client_ID = regexp.MustCompile(`client_id\s*[:=]\s*"(\w{32})"`)
+)
+
+// New_Client_ID scrapes a client ID from the web app script bundles.
+func New_Client_ID() (string, error) {
+   page, err := get_text("https://soundcloud.com")
+   if err != nil {
+      return "", err
+   }
+   scripts := script_src.FindAllStringSubmatch(page, -1)
+   // the ID is usually in one of the last bundles
+   for i := len(scripts) - 1; i >= 0; i-- {
+      script, err := get_text(scripts[i][1])
+      if err != nil {
+         return "", err
+      }
+      if match := script_client_ID.FindStringSubmatch(script); match != nil {
+         return match[1], nil
+      }
+   }
+   return "", errors.New("client ID is missing")
+}
+
+// Refresh_Client_ID sets Client_ID with New_Client_ID, and saves it to Cache.
+func Refresh_Client_ID() error {
+   client_mu.Lock()
+   defer client_mu.Unlock()
+   return refresh_client_ID()
+}
+
+// client_mu must be held
+func refresh_client_ID() error {
+   id, err := New_Client_ID()
+   if err != nil {
+      return err
+   }
+   if id == Client_ID {
+      return nil
+   }
+   Client_ID = id
+   if Cache == "" {
+      return nil
+   }
+   if err := os.MkdirAll(filepath.Dir(Cache), 0755); err != nil {
+      return err
+   }
+   return os.WriteFile(Cache, []byte(id), 0644)
+}
+
+func get_text(addr string) (string, error) {
+   req, err := http.NewRequest("GET", addr, nil)
+   if err != nil {
+      return "", err
+   }
+   Log.Dump(req)
+   res, err := new(http.Transport).RoundTrip(req)
+   if err != nil {
+      return "", err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return "", errors.New(res.Status)
+   }
+   buf, err := io.ReadAll(res.Body)
+   if err != nil {
+      return "", err
+   }
+   return string(buf), nil
+}
+
+// on 401 or 403, refresh the client ID and try again
+func api(req *http.Request) (*http.Response, error) {
+   cache_once.Do(func() {
+      cache_err = Open_Cache(Cache)
+   })
+   if cache_err != nil {
+      return nil, cache_err
+   }
+   for retry := false; ; retry = true {
+      client_mu.Lock()
+      id := Client_ID
+      client_mu.Unlock()
+      val := req.URL.Query()
+      val.Set("client_id", id)
+      req.URL.RawQuery = val.Encode()
+      Log.Dump(req)
+      res, err := new(http.Transport).RoundTrip(req)
+      if err != nil {
+         return nil, err
+      }
+      rejected := res.StatusCode == http.StatusUnauthorized ||
+         res.StatusCode == http.StatusForbidden
+      if retry || !rejected {
+         return res, nil
+      }
+      fresh, err := refresh_once(id)
+      if err != nil {
+         res.Body.Close()
+         return nil, err
+      }
+      // same ID, so the resource itself is private or blocked
+      if fresh == id {
+         return res, nil
+      }
+      if err := res.Body.Close(); err != nil {
+         return nil, err
+      }
+   }
+}
+
+// returns the client ID to retry with
+func refresh_once(used string) (string, error) {
+   client_mu.Lock()
+   defer client_mu.Unlock()
+   // another request already refreshed it
+   if Client_ID != used || client_refreshed {
+      return Client_ID, nil
+   }
+   client_refreshed = true
+   if err := refresh_client_ID(); err != nil {
+      return "", err
+   }
+   return Client_ID, nil
+}
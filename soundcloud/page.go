@@ -0,0 +1,70 @@
+package soundcloud
+
+import (
+   "encoding/json"
+   "errors"
+   "net/http"
+   "net/url"
+)
+
+// Scanner pages through a listing by following next_href.
+type Scanner[T any] struct {
+   err error
+   next string
+   page []T
+}
+
+func new_scanner[T any](addr string, val url.Values) *Scanner[T] {
+   val.Set("limit", "200")
+   val.Set("linked_partitioning", "1")
+   return &Scanner[T]{next: addr + "?" + val.Encode()}
+}
+
+func (s *Scanner[T]) Scan() bool {
+   if s.err != nil || s.next == "" {
+      return false
+   }
+   req, err := http.NewRequest("GET", s.next, nil)
+   if err != nil {
+      s.err = err
+      return false
+   }
+   // next_href leaves out the client_id
+   res, err := api(req)
+   if err != nil {
+      s.err = err
+      return false
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      s.err = errors.New(res.Status)
+      return false
+   }
+   var page struct {
+      Collection []T
+      Next_Href string
+   }
+   if err := json.NewDecoder(res.Body).Decode(&page); err != nil {
+      s.err = err
+      return false
+   }
+   s.next, s.page = page.Next_Href, page.Collection
+   return true
+}
+
+func (s Scanner[T]) Page() []T {
+   return s.page
+}
+
+func (s Scanner[T]) Err() error {
+   return s.err
+}
+
+// All collects the rest of the listing.
+func (s *Scanner[T]) All() ([]T, error) {
+   var items []T
+   for s.Scan() {
+      items = append(items, s.Page()...)
+   }
+   return items, s.Err()
+}
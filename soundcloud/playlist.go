@@ -0,0 +1,116 @@
+package soundcloud
+
+import (
+   "encoding/json"
+   "errors"
+   "net/http"
+   "net/url"
+   "strconv"
+   "strings"
+)
+
+type Playlist struct {
+   Kind string // playlist, system-playlist
+   Title string
+   Permalink_URL string
+   User User
+   Track_Count int64
+   // only the first few tracks are complete, the rest have only ID
+   Tracks []Track
+}
+
+// fetch any tracks that are incomplete
+func (p Playlist) Full_Tracks() ([]Track, error) {
+   var ids []int64
+   for _, track := range p.Tracks {
+      if track.Title == "" {
+         ids = append(ids, track.ID)
+      }
+   }
+   full := make(map[int64]Track)
+   // the tracks endpoint allows 50 IDs at a time
+   for len(ids) >= 1 {
+      n := 50
+      if len(ids) < n {
+         n = len(ids)
+      }
+      tracks, err := get_tracks(ids[:n])
+      if err != nil {
+         return nil, err
+      }
+      for _, track := range tracks {
+         full[track.ID] = track
+      }
+      ids = ids[n:]
+   }
+   tracks := make([]Track, 0, len(p.Tracks))
+   for _, track := range p.Tracks {
+      if track.Title == "" {
+         var ok bool
+         track, ok = full[track.ID]
+         if !ok {
+            // removed or private
+            continue
+         }
+      }
+      tracks = append(tracks, track)
+   }
+   return tracks, nil
+}
+
+func get_tracks(ids []int64) ([]Track, error) {
+   var buf strings.Builder
+   for i, id := range ids {
+      if i >= 1 {
+         buf.WriteByte(',')
+      }
+      buf.WriteString(strconv.FormatInt(id, 10))
+   }
+   req, err := http.NewRequest("GET", "https://api-v2.soundcloud.com/tracks", nil)
+   if err != nil {
+      return nil, err
+   }
+   req.URL.RawQuery = url.Values{
+      "ids": {buf.String()},
+   }.Encode()
+   res, err := api(req)
+   if err != nil {
+      return nil, err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
+   var tracks []Track
+   if err := json.NewDecoder(res.Body).Decode(&tracks); err != nil {
+      return nil, err
+   }
+   return tracks, nil
+}
+
+type Like struct {
+   Created_At string
+   Track *Track
+   Playlist *Playlist
+}
+
+func User_Likes(id int64) *Scanner[Like] {
+   buf := []byte("https://api-v2.soundcloud.com/users/")
+   buf = strconv.AppendInt(buf, id, 10)
+   buf = append(buf, "/likes"...)
+   return new_scanner[Like](string(buf), url.Values{})
+}
+
+type Repost struct {
+   Created_At string
+   Type string // track-repost, playlist-repost
+   Track *Track
+   Playlist *Playlist
+}
+
+func User_Reposts(id int64) *Scanner[Repost] {
+   buf := []byte("https://api-v2.soundcloud.com/stream/users/")
+   buf = strconv.AppendInt(buf, id, 10)
+   buf = append(buf, "/reposts"...)
+   return new_scanner[Repost](string(buf), url.Values{})
+}
@@ -0,0 +1,58 @@
+package soundcloud
+
+import (
+   "net/url"
+)
+
+type User struct {
+   ID int64
+   Username string
+   Avatar_URL string
+   Permalink_URL string
+   Followers_Count int64
+   Track_Count int64
+}
+
+// filters apply to tracks
+type Search struct {
+   Query string
+   // genre or tag, for example "house"
+   Genre string
+   // short, medium, long, epic
+   Duration string
+   // last_hour, last_day, last_week, last_month, last_year
+   Created_At string
+}
+
+func (s Search) values() url.Values {
+   val := url.Values{"q": {s.Query}}
+   if s.Genre != "" {
+      val.Set("filter.genre_or_tag", s.Genre)
+   }
+   if s.Duration != "" {
+      val.Set("filter.duration", s.Duration)
+   }
+   if s.Created_At != "" {
+      val.Set("filter.created_at", s.Created_At)
+   }
+   return val
+}
+
+func (s Search) Tracks() *Scanner[Track] {
+   return new_scanner[Track](
+      "https://api-v2.soundcloud.com/search/tracks", s.values(),
+   )
+}
+
+func (s Search) Users() *Scanner[User] {
+   return new_scanner[User](
+      "https://api-v2.soundcloud.com/search/users", s.values(),
+   )
+}
+
+// use Full_Tracks for the tracks
+func (s Search) Playlists() *Scanner[Playlist] {
+   return new_scanner[Playlist](
+      "https://api-v2.soundcloud.com/search/playlists", s.values(),
+   )
+}
@@ -19,8 +19,6 @@ func (m Media) Ext() (string, error) {
    return path.Ext(addr.Path), nil
 }
 
-const client_id = "iZIs9mchVcX5lhVRyQGGAYlNPVldzAoX"
-
 var Log format.Log
 
 type Image struct {
@@ -3,57 +3,103 @@ package soundcloud
 
 import (
    "encoding/json"
+   "errors"
+   "github.com/89z/mech"
+   "io"
    "net/http"
    "net/url"
+   "path"
    "strconv"
    "strings"
    "time"
 )
 
-// Also available is "hls", but all transcodings are quality "sq".
-// Same for "api-mobile.soundcloud.com".
+// Progressive returns the progressive MP3, if the track has one. Many
+// tracks only have HLS, so see also Select.
 func (t Track) Progressive() (*Media, error) {
-   var addr string
    for _, code := range t.Media.Transcodings {
       if code.Format.Protocol == "progressive" {
-         addr = code.URL
+         return code.Media()
       }
    }
-   req, err := http.NewRequest("GET", addr, nil)
-   if err != nil {
-      return nil, err
-   }
-   req.URL.RawQuery = "client_id=" + client_id
-   Log.Dump(req)
-   res, err := new(http.Transport).RoundTrip(req)
-   if err != nil {
-      return nil, err
-   }
-   defer res.Body.Close()
-   med := new(Media)
-   if err := json.NewDecoder(res.Body).Decode(med); err != nil {
-      return nil, err
-   }
-   return med, nil
+   return nil, errors.New("progressive transcoding is missing")
 }
 
 type Track struct {
    ID int64
    Display_Date string // 2021-04-12T07:00:01Z
-   User struct {
-      Username string
-      Avatar_URL string
-   }
+   User User
    Title string
    Artwork_URL string
    Media struct {
-      Transcodings []struct {
-         Format struct {
-            Protocol string
+      Transcodings []Transcoding
+   }
+   Description string
+   Genre string
+   Tag_List string // rap "hip hop"
+   Duration int64 // milliseconds
+   Playback_Count int64
+   Likes_Count int64
+   Reposts_Count int64
+   Comment_Count int64
+   License string // all-rights-reserved, cc-by
+   Permalink_URL string
+   Waveform_URL string
+   Publisher_Metadata *struct {
+      Album_Title string
+      Artist string
+      ISRC string
+      Release_Title string
+   }
+}
+
+// Tags splits Tag_List, where tags with spaces are quoted.
+func (t Track) Tags() []string {
+   var tags []string
+   for rest := strings.TrimSpace(t.Tag_List); rest != ""; {
+      var tag string
+      if strings.HasPrefix(rest, `"`) {
+         var ok bool
+         tag, rest, ok = strings.Cut(rest[1:], `"`)
+         if !ok {
+            tag = strings.TrimSuffix(tag, `"`)
          }
-         URL string
+      } else {
+         tag, rest, _ = strings.Cut(rest, " ")
+      }
+      if tag != "" {
+         tags = append(tags, tag)
+      }
+      rest = strings.TrimSpace(rest)
+   }
+   return tags
+}
+
+func (t Track) Length() time.Duration {
+   return time.Duration(t.Duration) * time.Millisecond
+}
+
+// Metadata returns tags for the audio file, preferring the publisher
+// metadata.
+func (t Track) Metadata() mech.Tags {
+   tags := mech.Tags{
+      Artist: t.User.Username,
+      Genre: t.Genre,
+      Title: t.Title,
+   }
+   if len(t.Display_Date) >= 4 {
+      tags.Date = t.Display_Date[:4]
+   }
+   if pub := t.Publisher_Metadata; pub != nil {
+      if pub.Artist != "" {
+         tags.Artist = pub.Artist
+      }
+      tags.Album = pub.Album_Title
+      if tags.Album == "" {
+         tags.Album = pub.Release_Title
       }
    }
+   return tags
 }
 
 func New_Track(id int64) (*Track, error) {
@@ -63,13 +109,14 @@ func New_Track(id int64) (*Track, error) {
    if err != nil {
       return nil, err
    }
-   req.URL.RawQuery = "client_id=" + client_id
-   Log.Dump(req)
-   res, err := new(http.Transport).RoundTrip(req)
+   res, err := api(req)
    if err != nil {
       return nil, err
    }
    defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
    tra := new(Track)
    if err := json.NewDecoder(res.Body).Decode(tra); err != nil {
       return nil, err
@@ -77,70 +124,161 @@ func New_Track(id int64) (*Track, error) {
    return tra, nil
 }
 
+// Resolve returns the tracks for a track, playlist, user, likes or reposts
+// address. For likes and reposts, only the tracks are returned, not the
+// playlists.
 func Resolve(addr string) ([]Track, error) {
-   req, err := http.NewRequest(
-      "GET", "https://api-v2.soundcloud.com/resolve", nil,
-   )
+   user_addr, listing, err := split_listing(addr)
    if err != nil {
       return nil, err
    }
-   req.URL.RawQuery = url.Values{
-      "client_id": {client_id},
-      "url": {addr},
-   }.Encode()
-   Log.Dump(req)
-   res, err := new(http.Transport).RoundTrip(req)
+   if listing != "" {
+      user, err := resolve(user_addr)
+      if err != nil {
+         return nil, err
+      }
+      if user.Kind != "user" {
+         return nil, invalid_kind{user.Kind}
+      }
+      if listing == "likes" {
+         likes, err := User_Likes(user.ID).All()
+         if err != nil {
+            return nil, err
+         }
+         var tracks []Track
+         for _, like := range likes {
+            if like.Track != nil {
+               tracks = append(tracks, *like.Track)
+            }
+         }
+         return tracks, nil
+      }
+      reposts, err := User_Reposts(user.ID).All()
+      if err != nil {
+         return nil, err
+      }
+      var tracks []Track
+      for _, repost := range reposts {
+         if repost.Track != nil {
+            tracks = append(tracks, *repost.Track)
+         }
+      }
+      return tracks, nil
+   }
+   solve, err := resolve(addr)
    if err != nil {
       return nil, err
    }
-   defer res.Body.Close()
-   var solve struct {
-      Kind string
-      Track
+   switch solve.Kind {
+   case "track":
+      var track Track
+      if err := json.Unmarshal(solve.raw, &track); err != nil {
+         return nil, err
+      }
+      return []Track{track}, nil
+   case "playlist", "system-playlist":
+      var list Playlist
+      if err := json.Unmarshal(solve.raw, &list); err != nil {
+         return nil, err
+      }
+      return list.Full_Tracks()
+   case "user":
+      return User_Tracks(solve.ID).All()
    }
-   if err := json.NewDecoder(res.Body).Decode(&solve); err != nil {
-      return nil, err
+   return nil, invalid_kind{solve.Kind}
+}
+
+// split a likes or reposts address into the user address and the listing
+func split_listing(addr string) (string, string, error) {
+   parse, err := url.Parse(addr)
+   if err != nil {
+      return "", "", err
    }
-   if solve.Kind == "track" {
-      return []Track{solve.Track}, nil
+   dir, listing := path.Split(strings.TrimSuffix(parse.Path, "/"))
+   switch listing {
+   case "likes", "reposts":
+      parse.Path = strings.TrimSuffix(dir, "/")
+      parse.RawPath = ""
+      parse.RawQuery = ""
+      parse.Fragment = ""
+      return parse.String(), listing, nil
    }
-   return User_Tracks(solve.ID)
+   return "", "", nil
 }
 
-// We can also paginate, but for now this is good enough.
-func User_Tracks(id int64) ([]Track, error) {
-   buf := []byte("https://api-v2.soundcloud.com/users/")
-   buf = strconv.AppendInt(buf, id, 10)
-   buf = append(buf, "/tracks"...)
-   req, err := http.NewRequest("GET", string(buf), nil)
+type resolved struct {
+   ID int64
+   Kind string
+   raw []byte
+}
+
+func resolve(addr string) (*resolved, error) {
+   req, err := http.NewRequest(
+      "GET", "https://api-v2.soundcloud.com/resolve", nil,
+   )
    if err != nil {
       return nil, err
    }
    req.URL.RawQuery = url.Values{
-      "client_id": {client_id},
-      "limit": {"999"},
+      "url": {addr},
    }.Encode()
-   Log.Dump(req)
-   res, err := new(http.Transport).RoundTrip(req)
+   res, err := api(req)
    if err != nil {
       return nil, err
    }
    defer res.Body.Close()
-   var user struct {
-      Collection []Track
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
+   var solve resolved
+   solve.raw, err = io.ReadAll(res.Body)
+   if err != nil {
+      return nil, err
    }
-   if err := json.NewDecoder(res.Body).Decode(&user); err != nil {
+   // system playlists have a string ID
+   var kind struct {
+      Kind string
+   }
+   if err := json.Unmarshal(solve.raw, &kind); err != nil {
       return nil, err
    }
-   return user.Collection, nil
+   solve.Kind = kind.Kind
+   if solve.Kind == "track" || solve.Kind == "user" {
+      if err := json.Unmarshal(solve.raw, &solve); err != nil {
+         return nil, err
+      }
+   }
+   return &solve, nil
+}
+
+type invalid_kind struct {
+   value string
+}
+
+func (i invalid_kind) Error() string {
+   return "invalid kind " + strconv.Quote(i.value)
+}
+
+// User_Tracks returns a Scanner over every track uploaded by a user.
+func User_Tracks(id int64) *Scanner[Track] {
+   buf := []byte("https://api-v2.soundcloud.com/users/")
+   buf = strconv.AppendInt(buf, id, 10)
+   buf = append(buf, "/tracks"...)
+   return new_scanner[Track](string(buf), url.Values{})
 }
 
 // i1.sndcdn.com/artworks-000308141235-7ep8lo-large.jpg
 func (t Track) Artwork() string {
+   return t.Artwork_Size("t500x")
+}
+
+// Artwork_Size returns the artwork address for a Size from Images, or
+// "original". If the track has no artwork, the user avatar is used.
+func (t Track) Artwork_Size(size string) string {
    if t.Artwork_URL == "" {
       t.Artwork_URL = t.User.Avatar_URL
    }
-   return strings.Replace(t.Artwork_URL, "large", "t500x", 1)
+   return strings.Replace(t.Artwork_URL, "-large.", "-" + size + ".", 1)
 }
 
 func (t Track) Base() string {
@@ -159,6 +297,28 @@ func (t Track) String() string {
    buf = append(buf, t.User.Avatar_URL...)
    buf = append(buf, "\nTitle: "...)
    buf = append(buf, t.Title...)
+   buf = append(buf, "\nDuration: "...)
+   buf = append(buf, t.Length().String()...)
+   if t.Genre != "" {
+      buf = append(buf, "\nGenre: "...)
+      buf = append(buf, t.Genre...)
+   }
+   if tags := t.Tags(); len(tags) >= 1 {
+      buf = append(buf, "\nTags: "...)
+      buf = append(buf, strings.Join(tags, ", ")...)
+   }
+   buf = append(buf, "\nPlays: "...)
+   buf = strconv.AppendInt(buf, t.Playback_Count, 10)
+   buf = append(buf, "\nLikes: "...)
+   buf = strconv.AppendInt(buf, t.Likes_Count, 10)
+   buf = append(buf, "\nLicense: "...)
+   buf = append(buf, t.License...)
+   buf = append(buf, "\nURL: "...)
+   buf = append(buf, t.Permalink_URL...)
+   if pub := t.Publisher_Metadata; pub != nil && pub.ISRC != "" {
+      buf = append(buf, "\nISRC: "...)
+      buf = append(buf, pub.ISRC...)
+   }
    if t.Artwork_URL != "" {
       buf = append(buf, "\nArtwork: "...)
       buf = append(buf, t.Artwork_URL...)
@@ -166,8 +326,12 @@ func (t Track) String() string {
    for _, coding := range t.Media.Transcodings {
       buf = append(buf, "\nFormat: "...)
       buf = append(buf, coding.Format.Protocol...)
-      buf = append(buf, "\nURL: "...)
-      buf = append(buf, coding.URL...)
+      buf = append(buf, "\nType: "...)
+      buf = append(buf, coding.Format.Mime_Type...)
+      buf = append(buf, "\nPreset: "...)
+      buf = append(buf, coding.Preset...)
+      buf = append(buf, "\nQuality: "...)
+      buf = append(buf, coding.Quality...)
    }
    return string(buf)
 }
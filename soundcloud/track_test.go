@@ -2,6 +2,9 @@ package soundcloud
 
 import (
    "fmt"
+   "github.com/89z/mech"
+   "io"
+   "strings"
    "testing"
    "time"
 )
@@ -14,6 +17,8 @@ type item_type struct {
 var items = []item_type{
    {936653761, "https://soundcloud.com/kino-scmusic/mqymd53jtwag"},
    {692707328, "https://soundcloud.com/kino-scmusic"},
+   {692707328, "https://soundcloud.com/kino-scmusic/likes"},
+   {692707328, "https://soundcloud.com/kino-scmusic/reposts"},
 }
 
 func Test_Resolve(t *testing.T) {
@@ -39,4 +44,118 @@ func Test_Track(t *testing.T) {
       t.Fatal(err)
    }
    fmt.Printf("%+v\n", media)
+   sel, err := mech.New_Selector("protocol=hls,prefer=hq")
+   if err != nil {
+      t.Fatal(err)
+   }
+   code, err := track.Select(*sel)
+   if err != nil {
+      t.Fatal(err)
+   }
+   ext, err := code.Ext()
+   if err != nil {
+      t.Fatal(err)
+   }
+   if err := code.Encode(io.Discard); err != nil {
+      t.Fatal(err)
+   }
+   fmt.Println(code.Preset, ext)
+}
+
+func Test_User_Tracks(t *testing.T) {
+   scan := User_Tracks(items[1].id)
+   for scan.Scan() {
+      for _, track := range scan.Page() {
+         fmt.Println(track.ID, track.Title)
+      }
+   }
+   if err := scan.Err(); err != nil {
+      t.Fatal(err)
+   }
+}
+
+func Test_Search(t *testing.T) {
+   search := Search{Query: "kino", Duration: "medium"}
+   scan := search.Tracks()
+   if !scan.Scan() {
+      t.Fatal(scan.Err())
+   }
+   for _, track := range scan.Page() {
+      fmt.Println(track.ID, track.Title)
+   }
+}
+
+func Test_Client_ID(t *testing.T) {
+   id, err := New_Client_ID()
+   if err != nil {
+      t.Fatal(err)
+   }
+   fmt.Println(id)
+}
+
+func Test_Tags(t *testing.T) {
+   track := Track{Tag_List: `rap "hip hop"  lofi "new york"`}
+   tags := fmt.Sprint(track.Tags())
+   if tags != "[rap hip hop lofi new york]" {
+      t.Fatal(tags)
+   }
+}
+
+var listings = map[string][2]string{
+   "https://soundcloud.com/kino-scmusic/likes": {
+      "https://soundcloud.com/kino-scmusic", "likes",
+   },
+   "https://soundcloud.com/kino-scmusic/likes/": {
+      "https://soundcloud.com/kino-scmusic", "likes",
+   },
+   "https://soundcloud.com/kino-scmusic/reposts?ref=clipboard": {
+      "https://soundcloud.com/kino-scmusic", "reposts",
+   },
+   "https://soundcloud.com/kino-scmusic/mqymd53jtwag": {},
+}
+
+func Test_Listing(t *testing.T) {
+   for addr, want := range listings {
+      user, listing, err := split_listing(addr)
+      if err != nil {
+         t.Fatal(err)
+      }
+      if user != want[0] || listing != want[1] {
+         t.Fatal(addr, user, listing)
+      }
+   }
+}
+
+func Test_Artworks(t *testing.T) {
+   track := Track{
+      Artwork_URL: "https://i1.sndcdn.com/artworks-000308141235-7ep8lo-large.jpg",
+   }
+   addrs, err := track.Artworks("original")
+   if err != nil {
+      t.Fatal(err)
+   }
+   if len(addrs) != 4 || !strings.HasSuffix(addrs[1], "-original.png") {
+      t.Fatal(addrs)
+   }
+   if _, err := track.Artworks("t1x1"); err == nil {
+      t.Fatal("invalid size")
+   }
+}
+
+const playlist = `#EXTM3U
+#EXT-X-TARGETDURATION:10
+#EXT-X-MAP:URI="https://cf-hls-media.sndcdn.com/init/abc.mp4"
+#EXTINF:9.98,
+https://cf-hls-media.sndcdn.com/media/0/abc.m4s
+#EXT-X-ENDLIST
+`
+
+func Test_Map(t *testing.T) {
+   uri := map_URI([]byte(playlist))
+   if uri != "https://cf-hls-media.sndcdn.com/init/abc.mp4" {
+      t.Fatal(uri)
+   }
+   if uri := map_URI([]byte("#EXTM3U\n")); uri != "" {
+      t.Fatal(uri)
+   }
 }
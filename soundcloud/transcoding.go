@@ -0,0 +1,169 @@
+package soundcloud
+
+import (
+   "bufio"
+   "bytes"
+   "encoding/json"
+   "errors"
+   "github.com/89z/format/hls"
+   "github.com/89z/mech"
+   "io"
+   "net/http"
+   "strconv"
+   "strings"
+)
+
+type Transcoding struct {
+   Format struct {
+      Protocol string // progressive, hls
+      Mime_Type string // audio/ogg; codecs="opus"
+   }
+   Preset string // mp3_0_0, opus_0_0
+   Quality string // sq, hq
+   Snipped bool
+   URL string
+}
+
+// for HLS, the address is a playlist
+func (t Transcoding) Media() (*Media, error) {
+   req, err := http.NewRequest("GET", t.URL, nil)
+   if err != nil {
+      return nil, err
+   }
+   res, err := api(req)
+   if err != nil {
+      return nil, err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
+   med := new(Media)
+   if err := json.NewDecoder(res.Body).Decode(med); err != nil {
+      return nil, err
+   }
+   return med, nil
+}
+
+func (t Transcoding) Ext() (string, error) {
+   return mech.Extension_By_Type(t.Format.Mime_Type)
+}
+
+func (t Transcoding) Encode(w io.Writer) error {
+   med, err := t.Media()
+   if err != nil {
+      return err
+   }
+   if t.Format.Protocol == "progressive" {
+      return copy_get(w, med.URL)
+   }
+   if t.Format.Protocol != "hls" {
+      return errors.New("protocol " + t.Format.Protocol + " is not supported")
+   }
+   req, err := http.NewRequest("GET", med.URL, nil)
+   if err != nil {
+      return err
+   }
+   Log.Dump(req)
+   res, err := new(http.Transport).RoundTrip(req)
+   if err != nil {
+      return err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return errors.New(res.Status)
+   }
+   buf, err := io.ReadAll(res.Body)
+   if err != nil {
+      return err
+   }
+   seg, err := hls.New_Scanner(bytes.NewReader(buf)).Segment()
+   if err != nil {
+      return err
+   }
+   if len(seg.Protected) >= 1 {
+      return errors.New("HLS segments are encrypted")
+   }
+   // MP4 segments need the init segment first
+   if init := map_URI(buf); init != "" {
+      seg.Clear = append([]string{init}, seg.Clear...)
+   }
+   for _, clear := range seg.Clear {
+      addr, err := req.URL.Parse(clear)
+      if err != nil {
+         return err
+      }
+      if err := copy_get(w, addr.String()); err != nil {
+         return err
+      }
+   }
+   return nil
+}
+
+// #EXT-X-MAP:URI="init.mp4"
+func map_URI(buf []byte) string {
+   scan := bufio.NewScanner(bytes.NewReader(buf))
+   for scan.Scan() {
+      _, attr, ok := strings.Cut(scan.Text(), "#EXT-X-MAP:")
+      if !ok {
+         continue
+      }
+      _, uri, ok := strings.Cut(attr, `URI="`)
+      if ok {
+         uri, _, _ = strings.Cut(uri, `"`)
+         return uri
+      }
+   }
+   return ""
+}
+
+func copy_get(w io.Writer, addr string) error {
+   req, err := http.NewRequest("GET", addr, nil)
+   if err != nil {
+      return err
+   }
+   Log.Dump(req)
+   res, err := new(http.Transport).RoundTrip(req)
+   if err != nil {
+      return err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return errors.New(res.Status)
+   }
+   _, err = io.Copy(w, res.Body)
+   return err
+}
+
+// codec, hq, preset, protocol, quality, type
+func (t Transcoding) Value(key string) string {
+   switch key {
+   case "codec":
+      codec, _, _ := strings.Cut(t.Preset, "_")
+      return codec
+   case "hq":
+      return strconv.FormatBool(t.Quality == "hq")
+   case "preset":
+      return t.Preset
+   case "protocol":
+      return t.Format.Protocol
+   case "quality":
+      return t.Quality
+   case "type":
+      return t.Format.Mime_Type
+   }
+   return ""
+}
+
+// codec=opus|mp3,protocol=hls,prefer=hq
+func (t Track) Select(sel mech.Selector) (*Transcoding, error) {
+   codes := t.Media.Transcodings
+   if len(codes) == 0 {
+      return nil, errors.New("transcodings are missing")
+   }
+   code, ok := mech.Choose(sel, codes)
+   if !ok {
+      return nil, errors.New("no transcoding matches")
+   }
+   return code, nil
+}
@@ -0,0 +1,122 @@
+package mech
+
+import (
+   "encoding/binary"
+   "fmt"
+   "github.com/edgeware/mp4ff/bits"
+   "github.com/edgeware/mp4ff/mp4"
+   "io"
+)
+
+type Tags struct {
+   Album string
+   Album_Artist string
+   Artist string
+   Date string
+   Genre string
+   Title string
+   Track_Number int
+}
+
+// iTunes style tags
+func (t Tags) Tag_MP4(dst io.Writer, src io.Reader) error {
+   file, err := mp4.DecodeFile(src)
+   if err != nil {
+      return err
+   }
+   hdlr, err := mp4.CreateHdlr("mdir")
+   if err != nil {
+      return err
+   }
+   ilst := new(mp4.IlstBox)
+   for _, text := range []struct {
+      name, value string
+   }{
+      {"\xa9nam", t.Title},
+      {"\xa9ART", t.Artist},
+      {"aART", t.Album_Artist},
+      {"\xa9alb", t.Album},
+      {"\xa9day", t.Date},
+      {"\xa9gen", t.Genre},
+   } {
+      if text.value != "" {
+         ilst.AddChild(&tag_item{text.name, 1, []byte(text.value)})
+      }
+   }
+   if t.Track_Number >= 1 {
+      num := make([]byte, 8)
+      binary.BigEndian.PutUint16(num[2:], uint16(t.Track_Number))
+      ilst.AddChild(&tag_item{"trkn", 0, num})
+   }
+   meta := mp4.CreateMetaBox(0, hdlr)
+   meta.AddChild(ilst)
+   udta := new(mp4.UdtaBox)
+   udta.AddChild(meta)
+   if file.IsFragmented() {
+      file.Init.Moov.AddChild(udta)
+      return file.Encode(dst)
+   }
+   file.Moov.AddChild(udta)
+   // if moov comes first, then the chunks move by the size of udta
+   for _, box := range file.Children {
+      if box.Type() == "mdat" {
+         break
+      }
+      if box.Type() == "moov" {
+         for _, trak := range file.Moov.Traks {
+            stbl := trak.Mdia.Minf.Stbl
+            if stbl.Stco != nil {
+               for i := range stbl.Stco.ChunkOffset {
+                  stbl.Stco.ChunkOffset[i] += uint32(udta.Size())
+               }
+            }
+            if stbl.Co64 != nil {
+               for i := range stbl.Co64.ChunkOffset {
+                  stbl.Co64.ChunkOffset[i] += udta.Size()
+               }
+            }
+         }
+      }
+   }
+   return file.Encode(dst)
+}
+
+// item box holding one data box
+type tag_item struct {
+   name string
+   kind uint32
+   value []byte
+}
+
+func (t tag_item) Type() string {
+   return t.name
+}
+
+func (t tag_item) Size() uint64 {
+   return uint64(8 + 16 + len(t.value))
+}
+
+func (t tag_item) Encode(w io.Writer) error {
+   sw := bits.NewFixedSliceWriter(int(t.Size()))
+   if err := t.EncodeSW(sw); err != nil {
+      return err
+   }
+   _, err := w.Write(sw.Bytes())
+   return err
+}
+
+func (t tag_item) EncodeSW(sw bits.SliceWriter) error {
+   sw.WriteUint32(uint32(t.Size()))
+   sw.WriteString(t.name, false)
+   sw.WriteUint32(uint32(16 + len(t.value)))
+   sw.WriteString("data", false)
+   sw.WriteUint32(t.kind)
+   sw.WriteUint32(0)
+   sw.WriteBytes(t.value)
+   return sw.AccError()
+}
+
+func (t tag_item) Info(w io.Writer, _, indent, _ string) error {
+   _, err := fmt.Fprintf(w, "%s[%s] size=%d\n", indent, t.name, t.Size())
+   return err
+}
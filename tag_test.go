@@ -0,0 +1,34 @@
+package mech
+
+import (
+   "bytes"
+   "github.com/edgeware/mp4ff/mp4"
+   "testing"
+)
+
+func Test_Tag(t *testing.T) {
+   audio, err := new_fragmented("audio", 48000, 48000)
+   if err != nil {
+      t.Fatal(err)
+   }
+   tags := Tags{Artist: "Oneohtrix Point Never", Title: "Along", Track_Number: 2}
+   buf := new(bytes.Buffer)
+   if err := tags.Tag_MP4(buf, audio); err != nil {
+      t.Fatal(err)
+   }
+   file, err := mp4.DecodeFile(buf)
+   if err != nil {
+      t.Fatal(err)
+   }
+   for _, box := range file.Init.Moov.Children {
+      if udta, ok := box.(*mp4.UdtaBox); ok {
+         meta := udta.Children[0].(*mp4.MetaBox)
+         ilst := meta.Children[1].(*mp4.IlstBox)
+         if len(ilst.Children) != 3 {
+            t.Fatal(ilst.Children)
+         }
+         return
+      }
+   }
+   t.Fatal("udta")
+}
@@ -0,0 +1,97 @@
+package vimeo
+
+import (
+   "encoding/json"
+   "errors"
+   "net/http"
+   "net/http/cookiejar"
+   "net/url"
+   "strconv"
+   "strings"
+)
+
+type Option func(*options)
+
+type options struct {
+   password string
+}
+
+// Password unlocks a password protected video.
+func Password(s string) Option {
+   return func(o *options) {
+      o.password = s
+   }
+}
+
+type viewer struct {
+   JWT string
+   XSRFT string
+}
+
+func new_viewer(client *http.Client) (*viewer, error) {
+   req, err := http.NewRequest("GET", "https://vimeo.com/_rv/viewer", nil)
+   if err != nil {
+      return nil, err
+   }
+   req.Header.Set("X-Requested-With", "XMLHttpRequest")
+   Log.Dump(req)
+   res, err := client.Do(req)
+   if err != nil {
+      return nil, err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
+   view := new(viewer)
+   if err := json.NewDecoder(res.Body).Decode(view); err != nil {
+      return nil, err
+   }
+   return view, nil
+}
+
+// The site posts the password with the viewer session, after which the
+// session has access. The token from the viewer then works with the API.
+func verify_password(clip *Clip, password string) (*JSON_Web, error) {
+   jar, err := cookiejar.New(nil)
+   if err != nil {
+      return nil, err
+   }
+   client := &http.Client{Jar: jar}
+   view, err := new_viewer(client)
+   if err != nil {
+      return nil, err
+   }
+   ref := "https://vimeo.com/" + strconv.Itoa(clip.ID)
+   body := url.Values{
+      "password": {password},
+      "token": {view.XSRFT},
+   }.Encode()
+   req, err := http.NewRequest("POST", ref + "/password", strings.NewReader(body))
+   if err != nil {
+      return nil, err
+   }
+   req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+   req.Header.Set("Referer", ref)
+   req.Header.Set("X-Requested-With", "XMLHttpRequest")
+   Log.Dump(req)
+   res, err := client.Do(req)
+   if err != nil {
+      return nil, err
+   }
+   if err := res.Body.Close(); err != nil {
+      return nil, err
+   }
+   // the site answers a wrong password with 418
+   if res.StatusCode == http.StatusTeapot {
+      return nil, errors.New("password is incorrect")
+   }
+   if res.StatusCode != http.StatusOK {
+      return nil, errors.New(res.Status)
+   }
+   view, err = new_viewer(client)
+   if err != nil {
+      return nil, err
+   }
+   return &JSON_Web{view.JWT}, nil
+}
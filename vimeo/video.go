@@ -113,7 +113,21 @@ func New_JSON_Web() (*JSON_Web, error) {
    return web, nil
 }
 
-func (w JSON_Web) Video(clip *Clip) (*Video, error) {
+// Video returns the video and its downloads. With the Password option, the
+// password is verified first, and the token of that session is used instead
+// of w.
+func (w JSON_Web) Video(clip *Clip, opts ...Option) (*Video, error) {
+   var opt options
+   for _, each := range opts {
+      each(&opt)
+   }
+   if opt.password != "" {
+      web, err := verify_password(clip, opt.password)
+      if err != nil {
+         return nil, err
+      }
+      w = *web
+   }
    buf := fmt.Sprint("https://api.vimeo.com/videos/", clip.ID)
    if clip.UnlistedHash != "" {
       buf = fmt.Sprint(buf, ":", clip.UnlistedHash)
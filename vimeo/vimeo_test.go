@@ -0,0 +1,29 @@
+package vimeo
+
+import (
+   "testing"
+)
+
+// the password is "youtube-dl"
+const protected = "https://vimeo.com/68375962"
+
+func Test_Password(t *testing.T) {
+   clip, err := New_Clip(protected)
+   if err != nil {
+      t.Fatal(err)
+   }
+   web, err := New_JSON_Web()
+   if err != nil {
+      t.Fatal(err)
+   }
+   if _, err := web.Video(clip, Password("mech")); err == nil {
+      t.Fatal("wrong password was accepted")
+   }
+   vid, err := web.Video(clip, Password("youtube-dl"))
+   if err != nil {
+      t.Fatal(err)
+   }
+   if vid.Name == "" {
+      t.Fatal(vid)
+   }
+}
@@ -95,3 +95,34 @@ func Test_Android_Content(t *testing.T) {
       t.Fatal(play)
    }
 }
+
+func Test_Option(t *testing.T) {
+   loc, err := time.LoadLocation("Asia/Kolkata")
+   if err != nil {
+      t.Skip(err)
+   }
+   con := Mweb
+   for _, opt := range []Option{Language("hi"), Region("IN"), Time_Zone(loc)} {
+      opt(&con)
+   }
+   client := con.Context.Client
+   if client.HL != "hi" || client.GL != "IN" {
+      t.Fatal(client)
+   }
+   if client.Time_Zone != "Asia/Kolkata" || client.UTC_Offset_Minutes != 330 {
+      t.Fatal(client)
+   }
+   if Mweb.Context.Client.HL != "" {
+      t.Fatal(Mweb)
+   }
+}
+
+func Test_Search_Language(t *testing.T) {
+   search, err := Mweb.Search("oneohtrix point never", Language("de"), Region("DE"))
+   if err != nil {
+      t.Fatal(err)
+   }
+   for _, item := range search.Items() {
+      fmt.Println(item.CompactVideoRenderer)
+   }
+}
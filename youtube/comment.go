@@ -0,0 +1,265 @@
+package youtube
+
+import (
+   "encoding/json"
+   "errors"
+   "github.com/89z/mech"
+   "net/http"
+   "strconv"
+   "strings"
+)
+
+type Comment struct {
+   ID string
+   Author string
+   Text string
+   Like_Count int64
+   Published string
+   Reply_Count int64
+   Replies []Comment `json:",omitempty"`
+   reply_token string
+}
+
+type Comments struct {
+   Continuation string
+   Items []Comment
+}
+
+// Comments returns the first page of top-level comments. Use the Web config.
+func (c Config) Comments(id string) (*Comments, error) {
+   c.Video_ID = id
+   var next struct {
+      Contents struct {
+         TwoColumnWatchNextResults struct {
+            Results struct {
+               Results struct {
+                  Contents []struct {
+                     ItemSectionRenderer *struct {
+                        Contents []struct {
+                           ContinuationItemRenderer *continuation_item
+                        }
+                        SectionIdentifier string
+                     }
+                  }
+               }
+            }
+         }
+      }
+   }
+   if err := c.next(&next); err != nil {
+      return nil, err
+   }
+   results := next.Contents.TwoColumnWatchNextResults.Results.Results
+   for _, content := range results.Contents {
+      sect := content.ItemSectionRenderer
+      if sect != nil && sect.SectionIdentifier == "comment-item-section" {
+         for _, item := range sect.Contents {
+            if item.ContinuationItemRenderer != nil {
+               token := item.ContinuationItemRenderer.token()
+               return c.Comments_Continue(token)
+            }
+         }
+      }
+   }
+   return nil, errors.New("comments are disabled or missing")
+}
+
+// Comments_Continue returns the next page of top-level comments.
+func (c Config) Comments_Continue(token string) (*Comments, error) {
+   c.Continuation = token
+   var next continuation_response
+   if err := c.next(&next); err != nil {
+      return nil, err
+   }
+   var coms Comments
+   for _, item := range next.items() {
+      if item.CommentThreadRenderer != nil {
+         thread := item.CommentThreadRenderer
+         com := thread.Comment.CommentRenderer.comment()
+         if thread.Replies != nil {
+            for _, reply := range thread.Replies.CommentRepliesRenderer.Contents {
+               if reply.ContinuationItemRenderer != nil {
+                  com.reply_token = reply.ContinuationItemRenderer.token()
+               }
+            }
+         }
+         coms.Items = append(coms.Items, com)
+      }
+      if item.ContinuationItemRenderer != nil {
+         coms.Continuation = item.ContinuationItemRenderer.token()
+      }
+   }
+   return &coms, nil
+}
+
+// Replies fetches every reply to a top-level comment into com.Replies.
+func (c Config) Replies(com *Comment) error {
+   for token := com.reply_token; token != ""; {
+      c.Continuation = token
+      var next continuation_response
+      if err := c.next(&next); err != nil {
+         return err
+      }
+      token = ""
+      for _, item := range next.items() {
+         if item.CommentRenderer != nil {
+            com.Replies = append(com.Replies, item.CommentRenderer.comment())
+         }
+         if item.ContinuationItemRenderer != nil {
+            token = item.ContinuationItemRenderer.token()
+         }
+      }
+   }
+   return nil
+}
+
+func (c Config) next(value any) error {
+   buf, err := mech.Encode(c)
+   if err != nil {
+      return err
+   }
+   req, err := http.NewRequest("POST", origin + "/youtubei/v1/next", buf)
+   if err != nil {
+      return err
+   }
+   req.Header.Set("X-Goog-Api-Key", goog_API)
+   if c.User_Agent != "" {
+      req.Header.Set("User-Agent", c.User_Agent)
+   }
+   Log.Dump(req)
+   res, err := new(http.Transport).RoundTrip(req)
+   if err != nil {
+      return err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return errors.New(res.Status)
+   }
+   return json.NewDecoder(res.Body).Decode(value)
+}
+
+type continuation_item struct {
+   Button *struct {
+      ButtonRenderer struct {
+         Command continuation_endpoint
+      }
+   }
+   ContinuationEndpoint *continuation_endpoint
+}
+
+type continuation_endpoint struct {
+   ContinuationCommand struct {
+      Token string
+   }
+}
+
+func (c continuation_item) token() string {
+   if c.ContinuationEndpoint != nil {
+      return c.ContinuationEndpoint.ContinuationCommand.Token
+   }
+   if c.Button != nil {
+      return c.Button.ButtonRenderer.Command.ContinuationCommand.Token
+   }
+   return ""
+}
+
+type text struct {
+   Runs []struct {
+      Text string
+   }
+   SimpleText string
+}
+
+func (t text) String() string {
+   if t.SimpleText != "" {
+      return t.SimpleText
+   }
+   var buf strings.Builder
+   for _, run := range t.Runs {
+      buf.WriteString(run.Text)
+   }
+   return buf.String()
+}
+
+type comment_renderer struct {
+   AuthorText text
+   CommentId string
+   ContentText text
+   PublishedTimeText text
+   ReplyCount int64
+   VoteCount text
+}
+
+func (c comment_renderer) comment() Comment {
+   return Comment{
+      Author: c.AuthorText.String(),
+      ID: c.CommentId,
+      Like_Count: parse_count(c.VoteCount.String()),
+      Published: c.PublishedTimeText.String(),
+      Reply_Count: c.ReplyCount,
+      Text: c.ContentText.String(),
+   }
+}
+
+type continuation_response struct {
+   OnResponseReceivedEndpoints []struct {
+      AppendContinuationItemsAction *continuation_items
+      ReloadContinuationItemsCommand *continuation_items
+   }
+}
+
+type continuation_items struct {
+   ContinuationItems []comment_item
+}
+
+type comment_item struct {
+   CommentRenderer *comment_renderer
+   CommentThreadRenderer *struct {
+      Comment struct {
+         CommentRenderer comment_renderer
+      }
+      Replies *struct {
+         CommentRepliesRenderer struct {
+            Contents []struct {
+               ContinuationItemRenderer *continuation_item
+            }
+         }
+      }
+   }
+   ContinuationItemRenderer *continuation_item
+}
+
+func (c continuation_response) items() []comment_item {
+   var items []comment_item
+   for _, end := range c.OnResponseReceivedEndpoints {
+      for _, action := range []*continuation_items{
+         end.AppendContinuationItemsAction,
+         end.ReloadContinuationItemsCommand,
+      } {
+         if action != nil {
+            items = append(items, action.ContinuationItems...)
+         }
+      }
+   }
+   return items
+}
+
+// 1.2K
+func parse_count(s string) int64 {
+   mult := 1.0
+   switch {
+   case strings.HasSuffix(s, "K"):
+      mult = 1e3
+   case strings.HasSuffix(s, "M"):
+      mult = 1e6
+   case strings.HasSuffix(s, "B"):
+      mult = 1e9
+   }
+   s = strings.TrimRight(s, "KMB")
+   s = strings.ReplaceAll(s, ",", "")
+   num, err := strconv.ParseFloat(s, 64)
+   if err != nil {
+      return 0
+   }
+   return int64(num * mult)
+}
@@ -0,0 +1,37 @@
+package youtube
+
+import (
+   "fmt"
+   "testing"
+)
+
+var counts = map[string]int64{
+   "": 0,
+   "7": 7,
+   "1,234": 1234,
+   "1.2K": 1200,
+   "3M": 3_000_000,
+}
+
+func Test_Count(t *testing.T) {
+   for in, out := range counts {
+      if parse_count(in) != out {
+         t.Fatal(in)
+      }
+   }
+}
+
+func Test_Comments(t *testing.T) {
+   coms, err := Web.Comments(android)
+   if err != nil {
+      t.Fatal(err)
+   }
+   for _, com := range coms.Items {
+      if com.Reply_Count >= 1 {
+         if err := Web.Replies(&com); err != nil {
+            t.Fatal(err)
+         }
+      }
+      fmt.Printf("%+v\n", com)
+   }
+}
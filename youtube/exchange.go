@@ -1,10 +1,12 @@
 package youtube
 
 import (
+   "errors"
    "github.com/89z/format/json"
    "net/http"
    "net/url"
    "strings"
+   "time"
 )
 
 func (x Exchange) Create(name string) error {
@@ -26,9 +28,29 @@ const (
 type Exchange struct {
    Access_Token string
    Error string
+   Expires_In int64
+   Expiry time.Time
    Refresh_Token string
 }
 
+// Expired reports whether the access token has expired, or will within a
+// minute.
+func (x Exchange) Expired() bool {
+   return time.Now().Add(time.Minute).After(x.Expiry)
+}
+
+func (x *Exchange) decode(res *http.Response) error {
+   x.Error = ""
+   x.Expires_In = 0
+   if err := json.NewDecoder(res.Body).Decode(x); err != nil {
+      return err
+   }
+   if x.Expires_In >= 1 {
+      x.Expiry = time.Now().Add(time.Duration(x.Expires_In) * time.Second)
+   }
+   return nil
+}
+
 func (x *Exchange) Refresh() error {
    val := url.Values{
       "client_id": {client_id},
@@ -41,11 +63,19 @@ func (x *Exchange) Refresh() error {
       return err
    }
    defer res.Body.Close()
-   return json.NewDecoder(res.Body).Decode(x)
+   if err := x.decode(res); err != nil {
+      return err
+   }
+   if x.Error != "" {
+      return errors.New(x.Error)
+   }
+   return nil
 }
 
 type OAuth struct {
    Device_Code string
+   Expires_In int64
+   Interval int64
    User_Code string
    Verification_URL string
 }
@@ -80,18 +110,49 @@ func (o OAuth) Exchange() (*Exchange, error) {
    }
    defer res.Body.Close()
    exc := new(Exchange)
-   if err := json.NewDecoder(res.Body).Decode(exc); err != nil {
+   if err := exc.decode(res); err != nil {
       return nil, err
    }
    return exc, nil
 }
 
+// Poll calls Exchange at the interval given by the server, until the user
+// approves or denies the code, or the code expires.
+func (o OAuth) Poll() (*Exchange, error) {
+   interval := time.Duration(o.Interval) * time.Second
+   if interval <= 0 {
+      interval = 5 * time.Second
+   }
+   deadline := time.Now().Add(time.Duration(o.Expires_In) * time.Second)
+   for {
+      time.Sleep(interval)
+      exc, err := o.Exchange()
+      if err != nil {
+         return nil, err
+      }
+      switch exc.Error {
+      case "":
+         return exc, nil
+      case "authorization_pending":
+      case "slow_down":
+         interval += 5 * time.Second
+      case "expired_token":
+         return nil, errors.New("device code expired")
+      default:
+         return nil, errors.New(exc.Error)
+      }
+      if o.Expires_In >= 1 && time.Now().After(deadline) {
+         return nil, errors.New("device code expired")
+      }
+   }
+}
+
 func (o OAuth) String() string {
    var buf strings.Builder
    buf.WriteString("1. Go to\n")
    buf.WriteString(o.Verification_URL)
    buf.WriteString("\n\n2. Enter this code\n")
    buf.WriteString(o.User_Code)
-   buf.WriteString("\n\n3. Press Enter to continue")
+   buf.WriteString("\n\n3. Waiting for approval")
    return buf.String()
 }
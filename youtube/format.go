@@ -1,19 +1,40 @@
 package youtube
 
 import (
+   "errors"
    "fmt"
    "github.com/89z/format"
+   "github.com/89z/mech"
    "io"
    "mime"
    "net/http"
+   "strconv"
+   "strings"
 )
 
 func (f Format) Encode(w io.Writer) error {
+   if f.TargetDurationSec > 0 {
+      return errors.New("format is live, use Live instead")
+   }
    req, err := http.NewRequest("GET", f.URL, nil)
    if err != nil {
       return err
    }
    Log.Dump(req)
+   // muxed formats can be missing the length
+   if f.Content_Length <= 0 {
+      res, err := new(http.Client).Do(req)
+      if err != nil {
+         return err
+      }
+      defer res.Body.Close()
+      if res.StatusCode != http.StatusOK {
+         return errors.New(res.Status)
+      }
+      pro := format.Progress_Bytes(w, res.ContentLength)
+      _, err = io.Copy(pro, res.Body)
+      return err
+   }
    var (
       pos int64
       pro = format.Progress_Bytes(w, f.Content_Length)
@@ -56,19 +77,66 @@ func (f Formats) Video(height int) (*Format, bool) {
       }
       return height - f.Height
    }
-   var (
-      ok bool
-      output *Format
-   )
+   var output *Format
    for i, input := range f {
-      // since codecs are in this order avc1,vp9,av01,
-      // do "<=" so we can get last one
-      if output == nil || distance(&input) <= distance(output) {
+      if output == nil || distance(&input) < distance(output) {
          output = &f[i]
-         ok = true
+      } else if distance(&input) == distance(output) {
+         if input.Bitrate > output.Bitrate {
+            output = &f[i]
+         }
+      }
+   }
+   return output, output != nil
+}
+
+func (f Formats) Select(sel mech.Selector) (*Format, bool) {
+   return mech.Choose(sel, f)
+}
+
+// height width fps bitrate size type codec quality hdr
+func (f Format) Value(key string) string {
+   switch key {
+   case "bitrate":
+      return strconv.Itoa(f.Bitrate)
+   case "codec":
+      _, param, err := mime.ParseMediaType(f.MimeType)
+      if err != nil {
+         return f.MimeType
+      }
+      return param["codecs"]
+   case "fps":
+      if f.FPS >= 1 {
+         return strconv.Itoa(f.FPS)
+      }
+   case "hdr":
+      if strings.Contains(f.QualityLabel, "HDR") {
+         return "true"
+      }
+   case "height":
+      if f.Height >= 1 {
+         return strconv.Itoa(f.Height)
+      }
+   case "quality":
+      if f.QualityLabel != "" {
+         return f.QualityLabel
+      }
+      return f.AudioQuality
+   case "size":
+      if f.Content_Length >= 1 {
+         return strconv.FormatInt(f.Content_Length, 10)
+      }
+   case "type":
+      typ, _, err := mime.ParseMediaType(f.MimeType)
+      if err == nil {
+         return typ
+      }
+   case "width":
+      if f.Width >= 1 {
+         return strconv.Itoa(f.Width)
       }
    }
-   return output, ok
+   return ""
 }
 
 func (f Format) Format(s fmt.State, verb rune) {
@@ -104,9 +172,11 @@ type Format struct {
    AudioQuality string
    Bitrate int
    Content_Length int64 `json:"contentLength,string"`
+   FPS int
    Height int
    MimeType string
    QualityLabel string
+   TargetDurationSec float64
    URL string
    Width int
 }
@@ -2,6 +2,7 @@ package youtube
 
 import (
    "fmt"
+   "github.com/89z/mech"
    "mime"
    "testing"
 )
@@ -36,3 +37,40 @@ func Test_Format(t *testing.T) {
       fmt.Println(mime_type, exts)
    }
 }
+
+var formats = Formats{
+   {Height: 1080, FPS: 30, Bitrate: 4000, MimeType: `video/mp4; codecs="avc1.640028"`},
+   {Height: 1080, FPS: 60, Bitrate: 3000, MimeType: `video/webm; codecs="vp9"`},
+   {Height: 1080, FPS: 60, Bitrate: 2000, MimeType: `video/webm; codecs="vp9.2"`, QualityLabel: "1080p60 HDR"},
+   {Height: 2160, FPS: 60, Bitrate: 9000, MimeType: `video/mp4; codecs="av01.0.12M.08"`},
+   {Bitrate: 128, MimeType: `audio/webm; codecs="opus"`, AudioQuality: "AUDIO_QUALITY_MEDIUM"},
+   {Height: 720, FPS: 60, Bitrate: 5000, MimeType: `video/mp4; codecs="avc1.64001F"`},
+   {Height: 480, FPS: 30, Bitrate: 6000, MimeType: `video/webm; codecs="vp9"`},
+}
+
+var selects = map[string]int{
+   "height<=1080": 0,
+   "height<=1080,fps>=50": 1,
+   "height<=1080,codec=vp9|avc1,fps>=50,prefer=hdr": 2,
+   "codec!=avc1|vp9": 3,
+   "type=audio,quality=AUDIO_QUALITY_MEDIUM": 4,
+   // higher bitrate, lower height
+   "height<=720": 5,
+   "height<=1080,codec=vp9": 1,
+}
+
+func Test_Select(t *testing.T) {
+   for raw, index := range selects {
+      sel, err := mech.New_Selector(raw)
+      if err != nil {
+         t.Fatal(err)
+      }
+      form, ok := formats.Select(*sel)
+      if !ok || *form != formats[index] {
+         t.Fatal(raw, form)
+      }
+   }
+   if _, err := mech.New_Selector("height"); err == nil {
+      t.Fatal("height")
+   }
+}
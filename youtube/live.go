@@ -0,0 +1,277 @@
+package youtube
+
+import (
+   "bufio"
+   "errors"
+   "io"
+   "net/http"
+   "net/url"
+   "regexp"
+   "strconv"
+   "strings"
+   "time"
+)
+
+type Live_Segment struct {
+   Sequence int64
+   Duration time.Duration
+   URL *url.URL
+}
+
+type Live_Playlist struct {
+   End_List bool
+   Segments []Live_Segment
+   Target_Duration time.Duration
+}
+
+func New_Live_Playlist(r io.Reader, base *url.URL) (*Live_Playlist, error) {
+   var (
+      dur time.Duration
+      play Live_Playlist
+      seq int64
+   )
+   scan := bufio.NewScanner(r)
+   for scan.Scan() {
+      line := strings.TrimSpace(scan.Text())
+      key, val, _ := strings.Cut(line, ":")
+      switch {
+      case key == "#EXT-X-MEDIA-SEQUENCE":
+         var err error
+         seq, err = strconv.ParseInt(val, 10, 64)
+         if err != nil {
+            return nil, err
+         }
+      case key == "#EXT-X-TARGETDURATION":
+         sec, err := strconv.ParseFloat(val, 64)
+         if err != nil {
+            return nil, err
+         }
+         play.Target_Duration = time.Duration(sec * float64(time.Second))
+      case key == "#EXTINF":
+         val, _, _ = strings.Cut(val, ",")
+         sec, err := strconv.ParseFloat(val, 64)
+         if err != nil {
+            return nil, err
+         }
+         dur = time.Duration(sec * float64(time.Second))
+      case line == "#EXT-X-ENDLIST":
+         play.End_List = true
+      case line != "" && !strings.HasPrefix(line, "#"):
+         addr, err := base.Parse(line)
+         if err != nil {
+            return nil, err
+         }
+         play.Segments = append(play.Segments, Live_Segment{seq, dur, addr})
+         seq++
+      }
+   }
+   if err := scan.Err(); err != nil {
+      return nil, err
+   }
+   return &play, nil
+}
+
+func get_live_playlist(addr string) (*Live_Playlist, error) {
+   req, err := http.NewRequest("GET", addr, nil)
+   if err != nil {
+      return nil, err
+   }
+   Log.Dump(req)
+   res, err := new(http.Client).Do(req)
+   if err != nil {
+      return nil, err
+   }
+   defer res.Body.Close()
+   switch res.StatusCode {
+   case http.StatusOK:
+      return New_Live_Playlist(res.Body, res.Request.URL)
+   case http.StatusForbidden, http.StatusNotFound:
+      return nil, playlist_gone{res.Status}
+   }
+   return nil, errors.New(res.Status)
+}
+
+// the playlist goes away once the broadcast is over
+type playlist_gone struct {
+   status string
+}
+
+func (p playlist_gone) Error() string {
+   return p.status
+}
+
+// /sq/1234/
+var sequence_path = regexp.MustCompile(`/sq/\d+/`)
+
+// Live records a live broadcast from an HLS media playlist.
+type Live struct {
+   // media playlist
+   Address string
+   // start at the beginning of the DVR window, rather than now
+   From_Start bool
+   // stop after this much media, or zero for no limit
+   Duration time.Duration
+   // segments that could not be recorded
+   Gaps []int64
+}
+
+// Encode writes segments until the broadcast ends or the duration is reached.
+func (l *Live) Encode(w io.Writer) error {
+   play, err := get_live_playlist(l.Address)
+   if err != nil {
+      return err
+   }
+   if len(play.Segments) == 0 {
+      return errors.New("live playlist is empty")
+   }
+   template := play.Segments[0]
+   next := template.Sequence
+   if l.From_Start {
+      next, err = l.first_sequence(template)
+      if err != nil {
+         return err
+      }
+   }
+   var total time.Duration
+   // missing segments can only be asked for if the path has the number
+   by_number := sequence_path.MatchString(template.URL.Path)
+   // copy one segment, and report if the duration is reached
+   record := func(addr *url.URL, dur time.Duration) (bool, error) {
+      ok, err := l.copy(w, addr)
+      if err != nil {
+         return false, err
+      }
+      if ok {
+         total += dur
+      } else {
+         l.Gaps = append(l.Gaps, next)
+      }
+      next++
+      return l.Duration >= 1 && total >= l.Duration, nil
+   }
+   for {
+      for _, seg := range play.Segments {
+         // the playlist moved past some segments, so ask for them by number
+         for next < seg.Sequence {
+            if !by_number {
+               l.Gaps = append(l.Gaps, next)
+               next++
+               continue
+            }
+            done, err := record(template.at(next), template.Duration)
+            if err != nil || done {
+               return err
+            }
+         }
+         if seg.Sequence == next {
+            done, err := record(seg.URL, seg.Duration)
+            if err != nil || done {
+               return err
+            }
+         }
+      }
+      if play.End_List {
+         return nil
+      }
+      wait := play.Target_Duration
+      if wait <= 0 {
+         wait = 5 * time.Second
+      }
+      play, err = l.refresh(wait)
+      if err != nil {
+         if errors.As(err, new(playlist_gone)) {
+            return nil
+         }
+         return err
+      }
+   }
+}
+
+// try a few times, so one bad response does not end the recording
+func (l Live) refresh(wait time.Duration) (*Live_Playlist, error) {
+   var err error
+   for i := 0; i < 3; i++ {
+      time.Sleep(wait)
+      var play *Live_Playlist
+      play, err = get_live_playlist(l.Address)
+      if err == nil {
+         return play, nil
+      }
+      if errors.As(err, new(playlist_gone)) {
+         return nil, err
+      }
+   }
+   return nil, err
+}
+
+// binary search for the oldest segment still in the DVR window
+func (l Live) first_sequence(seg Live_Segment) (int64, error) {
+   if !sequence_path.MatchString(seg.URL.Path) {
+      return seg.Sequence, nil
+   }
+   low, high := int64(0), seg.Sequence
+   for low < high {
+      mid := (low + high) / 2
+      ok, err := head(seg.at(mid))
+      if err != nil {
+         return 0, err
+      }
+      if ok {
+         high = mid
+      } else {
+         low = mid + 1
+      }
+   }
+   return low, nil
+}
+
+// address of another segment, if the path includes the sequence number
+func (s Live_Segment) at(seq int64) *url.URL {
+   addr := *s.URL
+   addr.Path = sequence_path.ReplaceAllString(
+      addr.Path, "/sq/" + strconv.FormatInt(seq, 10) + "/",
+   )
+   if addr.RawPath != "" {
+      addr.RawPath = sequence_path.ReplaceAllString(
+         addr.RawPath, "/sq/" + strconv.FormatInt(seq, 10) + "/",
+      )
+   }
+   return &addr
+}
+
+func head(addr *url.URL) (bool, error) {
+   req, err := http.NewRequest("HEAD", addr.String(), nil)
+   if err != nil {
+      return false, err
+   }
+   Log.Dump(req)
+   res, err := new(http.Client).Do(req)
+   if err != nil {
+      return false, err
+   }
+   if err := res.Body.Close(); err != nil {
+      return false, err
+   }
+   return res.StatusCode == http.StatusOK, nil
+}
+
+// false if the segment is missing
+func (Live) copy(w io.Writer, addr *url.URL) (bool, error) {
+   req, err := http.NewRequest("GET", addr.String(), nil)
+   if err != nil {
+      return false, err
+   }
+   Log.Dump(req)
+   res, err := new(http.Client).Do(req)
+   if err != nil {
+      return false, err
+   }
+   defer res.Body.Close()
+   if res.StatusCode != http.StatusOK {
+      return false, nil
+   }
+   if _, err := io.Copy(w, res.Body); err != nil {
+      return false, err
+   }
+   return true, nil
+}
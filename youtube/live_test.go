@@ -0,0 +1,115 @@
+package youtube
+
+import (
+   "fmt"
+   "net/http"
+   "net/http/httptest"
+   "net/url"
+   "strings"
+   "testing"
+   "time"
+)
+
+const live_playlist = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:5
+#EXT-X-MEDIA-SEQUENCE:4012
+#EXTINF:5.0,
+https://manifest.googlevideo.com/api/manifest/hls_playlist/sq/4012/goap/seg.ts
+#EXTINF:5.0,
+https://manifest.googlevideo.com/api/manifest/hls_playlist/sq/4013/goap/seg.ts
+`
+
+func Test_Live_Playlist(t *testing.T) {
+   base, err := url.Parse("https://manifest.googlevideo.com/index.m3u8")
+   if err != nil {
+      t.Fatal(err)
+   }
+   play, err := New_Live_Playlist(strings.NewReader(live_playlist), base)
+   if err != nil {
+      t.Fatal(err)
+   }
+   if play.End_List || play.Target_Duration != 5*time.Second {
+      t.Fatal(play)
+   }
+   if len(play.Segments) != 2 || play.Segments[1].Sequence != 4013 {
+      t.Fatal(play.Segments)
+   }
+   addr := play.Segments[0].at(9).String()
+   if !strings.Contains(addr, "/sq/9/goap/") {
+      t.Fatal(addr)
+   }
+}
+
+// serve each playlist in turn, with segments that return their own path
+func live_server(lists ...string) *httptest.Server {
+   var n int
+   return httptest.NewServer(http.HandlerFunc(
+      func(w http.ResponseWriter, r *http.Request) {
+         if r.URL.Path != "/index.m3u8" {
+            fmt.Fprint(w, r.URL.Path)
+            return
+         }
+         if n >= len(lists) {
+            w.WriteHeader(http.StatusNotFound)
+            return
+         }
+         list := lists[n]
+         n++
+         if list == "" {
+            w.WriteHeader(http.StatusInternalServerError)
+            return
+         }
+         fmt.Fprint(w, list)
+      },
+   ))
+}
+
+func Test_Live_Refresh(t *testing.T) {
+   const head = "#EXTM3U\n#EXT-X-TARGETDURATION:0.001\n"
+   serve := live_server(
+      head + "#EXT-X-MEDIA-SEQUENCE:1\n#EXTINF:1,\na.ts\n",
+      "",
+      head + "#EXT-X-MEDIA-SEQUENCE:2\n#EXTINF:1,\nb.ts\n",
+   )
+   defer serve.Close()
+   var (
+      buf strings.Builder
+      live = Live{Address: serve.URL + "/index.m3u8"}
+   )
+   // one bad response, then the playlist is gone
+   if err := live.Encode(&buf); err != nil {
+      t.Fatal(err)
+   }
+   if buf.String() != "/a.ts/b.ts" {
+      t.Fatal(buf.String())
+   }
+   serve = live_server(head + "#EXTINF:1,\na.ts\n", "", "", "")
+   defer serve.Close()
+   live = Live{Address: serve.URL + "/index.m3u8"}
+   if err := live.Encode(new(strings.Builder)); err == nil {
+      t.Fatal("server error was ignored")
+   }
+}
+
+func Test_Live_Gaps(t *testing.T) {
+   const head = "#EXTM3U\n#EXT-X-TARGETDURATION:0.001\n"
+   serve := live_server(
+      head + "#EXT-X-MEDIA-SEQUENCE:1\n#EXTINF:1,\na.ts\n",
+      head + "#EXT-X-MEDIA-SEQUENCE:4\n#EXTINF:1,\nd.ts\n#EXT-X-ENDLIST\n",
+   )
+   defer serve.Close()
+   var (
+      buf strings.Builder
+      live = Live{Address: serve.URL + "/index.m3u8"}
+   )
+   if err := live.Encode(&buf); err != nil {
+      t.Fatal(err)
+   }
+   if buf.String() != "/a.ts/d.ts" {
+      t.Fatal(buf.String())
+   }
+   if fmt.Sprint(live.Gaps) != "[2 3]" {
+      t.Fatal(live.Gaps)
+   }
+}
@@ -0,0 +1,64 @@
+package youtube
+
+import (
+   "fmt"
+   "testing"
+   "time"
+)
+
+func Test_Music_Search(t *testing.T) {
+   items, err := Music.Music_Search("oneohtrix point never along")
+   if err != nil {
+      t.Fatal(err)
+   }
+   for _, item := range items {
+      fmt.Printf("%+v\n", item)
+   }
+}
+
+const music_album = "MPREb_bGwpf8Yr5EW"
+
+func Test_Album(t *testing.T) {
+   alb, err := Music.Album(music_album)
+   if err != nil {
+      t.Fatal(err)
+   }
+   fmt.Printf("%+v\n", alb)
+   time.Sleep(time.Second)
+   if len(alb.Tracks) == 0 {
+      t.Fatal(alb)
+   }
+   track, err := Music.Music_Track(alb.Tracks[0].Video_ID)
+   if err != nil {
+      t.Fatal(err)
+   }
+   if track.Track_Number != 1 {
+      t.Fatal(track)
+   }
+}
+
+const music_artist = "UCYLJwCOcRmXFDwLk1LvXFbA"
+
+func Test_Artist(t *testing.T) {
+   art, err := Music.Artist(music_artist)
+   if err != nil {
+      t.Fatal(err)
+   }
+   fmt.Printf("%+v\n", art)
+}
+
+func Test_Track_Number(t *testing.T) {
+   alb := Album{
+      Tracks: []Music_Track{
+         {Video_ID: "a", Title: "Intro", Track_Number: 1},
+         {Video_ID: "b", Title: "Along", Track_Number: 2},
+         {Video_ID: "c", Title: "Intro", Track_Number: 3},
+      },
+   }
+   if n := alb.track_number("c", "Intro"); n != 3 {
+      t.Fatal(n)
+   }
+   if n := alb.track_number("z", "Along"); n != 2 {
+      t.Fatal(n)
+   }
+}
@@ -30,8 +30,12 @@ func (p Player) Base() string {
 type Player struct {
    VideoDetails struct {
       Author string
+      IsLive bool
       LengthSeconds int64 `json:"lengthSeconds,string"`
       ShortDescription string
+      Thumbnail struct {
+         Thumbnails []Thumbnail
+      }
       Title string
       VideoId string
       ViewCount int64 `json:"viewCount,string"`
@@ -39,10 +43,21 @@ type Player struct {
    Microformat struct {
       PlayerMicroformatRenderer struct {
          PublishDate string
+         Thumbnail struct {
+            Thumbnails []Thumbnail
+         }
       }
    }
+   Storyboards_Spec struct {
+      PlayerStoryboardSpecRenderer struct {
+         Spec string
+      }
+   } `json:"storyboards"`
    StreamingData struct {
       AdaptiveFormats Formats
+      DashManifestUrl string
+      Formats Formats
+      HlsManifestUrl string
    }
    PlayabilityStatus Status
 }
@@ -52,6 +67,16 @@ type Status struct {
    Reason string
 }
 
+// Restricted is true if the video needs a login or an age check, rather
+// than a different client.
+func (s Status) Restricted() bool {
+   switch s.Status {
+   case "AGE_CHECK_REQUIRED", "CONTENT_CHECK_REQUIRED", "LOGIN_REQUIRED":
+      return true
+   }
+   return false
+}
+
 func (s Status) String() string {
    var buf strings.Builder
    buf.WriteString("Status: ")
@@ -73,6 +98,21 @@ func (p Player) Format(f fmt.State, verb rune) {
    if p.Date() != "" {
       fmt.Fprintln(f, "Date:", p.Date())
    }
+   if p.VideoDetails.IsLive {
+      fmt.Fprintln(f, "Live:", p.VideoDetails.IsLive)
+   }
+   if verb == 'a' {
+      if p.StreamingData.HlsManifestUrl != "" {
+         fmt.Fprintln(f, "HLS:", p.StreamingData.HlsManifestUrl)
+      }
+      if p.StreamingData.DashManifestUrl != "" {
+         fmt.Fprintln(f, "DASH:", p.StreamingData.DashManifestUrl)
+      }
+   }
+   for _, form := range p.StreamingData.Formats {
+      fmt.Fprintln(f)
+      form.Format(f, verb)
+   }
    for _, form := range p.StreamingData.AdaptiveFormats {
       fmt.Fprintln(f)
       form.Format(f, verb)
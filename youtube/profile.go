@@ -0,0 +1,129 @@
+package youtube
+
+import (
+   "errors"
+   "github.com/89z/format/json"
+   "strconv"
+)
+
+const android_version = "17.23.35"
+
+const android_agent =
+   "com.google.android.youtube/" + android_version + " (Linux; U; Android 11) gzip"
+
+type Profile struct {
+   Name string
+   Client Client
+   User_Agent string
+   Racy_Check_OK bool
+   Content_Check_OK bool
+   // profile only works with an OAuth token
+   OAuth bool
+}
+
+var Profiles = []Profile{
+   {
+      Name: "android",
+      Client: Client{
+         Android_SDK_Version: 30,
+         Client_Name: "ANDROID",
+         Client_Version: android_version,
+      },
+      User_Agent: android_agent,
+   },
+   {
+      Name: "android_embed",
+      Client: Client{
+         Android_SDK_Version: 30,
+         Client_Name: "ANDROID_EMBEDDED_PLAYER",
+         Client_Version: android_version,
+      },
+      User_Agent: android_agent,
+   },
+   {
+      Name: "android_racy",
+      Client: Client{
+         Android_SDK_Version: 30,
+         Client_Name: "ANDROID",
+         Client_Version: android_version,
+      },
+      User_Agent: android_agent,
+      Racy_Check_OK: true,
+      OAuth: true,
+   },
+   {
+      Name: "android_content",
+      Client: Client{
+         Android_SDK_Version: 30,
+         Client_Name: "ANDROID",
+         Client_Version: android_version,
+      },
+      User_Agent: android_agent,
+      Racy_Check_OK: true,
+      Content_Check_OK: true,
+      OAuth: true,
+   },
+}
+
+func Open_Profiles(name string) ([]Profile, error) {
+   pros, err := json.Open[[]Profile](name)
+   if err != nil {
+      return nil, err
+   }
+   return *pros, nil
+}
+
+func Find_Profile(pros []Profile, name string) (*Profile, error) {
+   for _, pro := range pros {
+      if pro.Name == name {
+         return &pro, nil
+      }
+   }
+   return nil, errors.New("profile " + strconv.Quote(name) + " is not found")
+}
+
+func (p Profile) Config() Config {
+   var con Config
+   con.Content_Check_OK = p.Content_Check_OK
+   con.Context.Client = p.Client
+   con.Racy_Check_OK = p.Racy_Check_OK
+   con.User_Agent = p.User_Agent
+   return con
+}
+
+// once a video is restricted, only OAuth profiles are tried
+func Player_Auto(
+   pros []Profile, id string, ex *Exchange, opts ...Option,
+) (*Player, error) {
+   var (
+      play *Player
+      restricted bool
+   )
+   for _, pro := range pros {
+      if pro.OAuth && ex == nil {
+         continue
+      }
+      if restricted && !pro.OAuth {
+         continue
+      }
+      var err error
+      if pro.OAuth {
+         play, err = pro.Config().Exchange(id, ex, opts...)
+      } else {
+         play, err = pro.Config().Player(id, opts...)
+      }
+      if err != nil {
+         return nil, err
+      }
+      if play.PlayabilityStatus.Status == "OK" {
+         return play, nil
+      }
+      if play.PlayabilityStatus.Restricted() {
+         restricted = true
+      }
+   }
+   if play == nil {
+      return nil, errors.New("no profile to try")
+   }
+   return play, nil
+}
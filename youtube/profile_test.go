@@ -0,0 +1,38 @@
+package youtube
+
+import (
+   "github.com/89z/format/json"
+   "path/filepath"
+   "testing"
+)
+
+func Test_Profile(t *testing.T) {
+   name := filepath.Join(t.TempDir(), "youtube-profiles.json")
+   if err := json.Create(Profiles, name); err != nil {
+      t.Fatal(err)
+   }
+   pros, err := Open_Profiles(name)
+   if err != nil {
+      t.Fatal(err)
+   }
+   pro, err := Find_Profile(pros, "android_embed")
+   if err != nil {
+      t.Fatal(err)
+   }
+   if pro.Config().Context != Android_Embed.Context {
+      t.Fatal(pro)
+   }
+   if _, err := Find_Profile(pros, "ios"); err == nil {
+      t.Fatal("ios")
+   }
+}
+
+func Test_Player_Auto(t *testing.T) {
+   play, err := Player_Auto(Profiles, android, nil)
+   if err != nil {
+      t.Fatal(err)
+   }
+   if play.PlayabilityStatus.Status != "OK" {
+      t.Fatal(play)
+   }
+}
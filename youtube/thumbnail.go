@@ -0,0 +1,138 @@
+package youtube
+
+import (
+   "errors"
+   "net/http"
+   "net/url"
+   "path"
+   "sort"
+   "strconv"
+   "strings"
+   "time"
+)
+
+type Thumbnail struct {
+   URL string
+   Width int
+   Height int
+}
+
+func (t Thumbnail) Ext() (string, error) {
+   addr, err := url.Parse(t.URL)
+   if err != nil {
+      return "", err
+   }
+   return path.Ext(addr.Path), nil
+}
+
+// largest first
+func (p Player) Thumbnails() []Thumbnail {
+   var thumbs []Thumbnail
+   thumbs = append(thumbs, p.VideoDetails.Thumbnail.Thumbnails...)
+   thumbs = append(
+      thumbs, p.Microformat.PlayerMicroformatRenderer.Thumbnail.Thumbnails...,
+   )
+   for _, img := range Images {
+      if strings.Contains(img.Base, "default") || strings.HasPrefix(img.Base, "hq720") {
+         thumbs = append(thumbs, Thumbnail{
+            URL: img.Format(p.VideoDetails.VideoId),
+            Width: img.Width,
+            Height: img.Height,
+         })
+      }
+   }
+   sort.SliceStable(thumbs, func(a, b int) bool {
+      return thumbs[a].Width * thumbs[a].Height > thumbs[b].Width * thumbs[b].Height
+   })
+   return thumbs
+}
+
+// Thumbnail returns the largest thumbnail that exists.
+func (p Player) Thumbnail() (*Thumbnail, error) {
+   for _, thumb := range p.Thumbnails() {
+      req, err := http.NewRequest("HEAD", thumb.URL, nil)
+      if err != nil {
+         return nil, err
+      }
+      Log.Dump(req)
+      res, err := new(http.Transport).RoundTrip(req)
+      if err != nil {
+         return nil, err
+      }
+      if err := res.Body.Close(); err != nil {
+         return nil, err
+      }
+      if res.StatusCode == http.StatusOK {
+         return &thumb, nil
+      }
+   }
+   return nil, errors.New("thumbnail is missing")
+}
+
+// one level of sprite sheets
+type Storyboard struct {
+   Width int
+   Height int
+   Count int
+   Columns int
+   Rows int
+   Interval time.Duration
+   name string
+   sigh string
+   base string
+   level int
+}
+
+// https://i.ytimg.com/sb/XY-hOqcPGCY/storyboard3_L$L/$N.jpg?sqp=-oaymwEn|
+// 48#27#100#10#10#0#default#rs$AOn4CLCE|80#45#96#10#10#2000#M$M#rs$AOn4CLDI
+func (p Player) Storyboards() ([]Storyboard, error) {
+   spec := p.Storyboards_Spec.PlayerStoryboardSpecRenderer.Spec
+   if spec == "" {
+      return nil, nil
+   }
+   levels := strings.Split(spec, "|")
+   var boards []Storyboard
+   for i, level := range levels[1:] {
+      field := strings.Split(level, "#")
+      if len(field) < 8 {
+         return nil, errors.New("invalid storyboard " + strconv.Quote(level))
+      }
+      var (
+         board Storyboard
+         nums [6]int
+      )
+      for j := range nums {
+         var err error
+         nums[j], err = strconv.Atoi(field[j])
+         if err != nil {
+            return nil, err
+         }
+      }
+      board.Width, board.Height, board.Count = nums[0], nums[1], nums[2]
+      board.Columns, board.Rows = nums[3], nums[4]
+      board.Interval = time.Duration(nums[5]) * time.Millisecond
+      board.name, board.sigh = field[6], field[7]
+      board.base, board.level = levels[0], i
+      boards = append(boards, board)
+   }
+   return boards, nil
+}
+
+// URLs returns one address for each sprite sheet.
+func (s Storyboard) URLs() []string {
+   var pages int
+   if per := s.Columns * s.Rows; per >= 1 {
+      pages = (s.Count + per - 1) / per
+   }
+   if !strings.Contains(s.name, "$M") && pages >= 1 {
+      pages = 1
+   }
+   base := strings.Replace(s.base, "$L", strconv.Itoa(s.level), 1)
+   var addrs []string
+   for page := 0; page < pages; page++ {
+      name := strings.Replace(s.name, "$M", strconv.Itoa(page), 1)
+      addr := strings.Replace(base, "$N", name, 1)
+      addrs = append(addrs, addr + "&sigh=" + s.sigh)
+   }
+   return addrs
+}
@@ -0,0 +1,45 @@
+package youtube
+
+import (
+   "fmt"
+   "testing"
+)
+
+const spec =
+   "https://i.ytimg.com/sb/XY-hOqcPGCY/storyboard3_L$L/$N.jpg?sqp=-oaymwEn|" +
+   "48#27#100#10#10#0#default#rs$AOn4CLCE|" +
+   "80#45#196#10#10#2000#M$M#rs$AOn4CLDI"
+
+var storyboard_URLs = []string{
+   "https://i.ytimg.com/sb/XY-hOqcPGCY/storyboard3_L0/default.jpg?sqp=-oaymwEn&sigh=rs$AOn4CLCE",
+   "https://i.ytimg.com/sb/XY-hOqcPGCY/storyboard3_L1/M0.jpg?sqp=-oaymwEn&sigh=rs$AOn4CLDI",
+   "https://i.ytimg.com/sb/XY-hOqcPGCY/storyboard3_L1/M1.jpg?sqp=-oaymwEn&sigh=rs$AOn4CLDI",
+}
+
+func Test_Storyboard(t *testing.T) {
+   var play Player
+   play.Storyboards_Spec.PlayerStoryboardSpecRenderer.Spec = spec
+   boards, err := play.Storyboards()
+   if err != nil {
+      t.Fatal(err)
+   }
+   var addrs []string
+   for _, board := range boards {
+      addrs = append(addrs, board.URLs()...)
+   }
+   if fmt.Sprint(addrs) != fmt.Sprint(storyboard_URLs) {
+      t.Fatal(addrs)
+   }
+}
+
+func Test_Thumbnail(t *testing.T) {
+   play, err := Android.Player(id)
+   if err != nil {
+      t.Fatal(err)
+   }
+   thumb, err := play.Thumbnail()
+   if err != nil {
+      t.Fatal(err)
+   }
+   fmt.Printf("%+v\n", thumb)
+}
@@ -1,28 +1,101 @@
 package youtube
 
 import (
+   "errors"
    "github.com/89z/format"
    "net/url"
-   "path"
+   "regexp"
+   "strconv"
    "strings"
+   "time"
 )
 
 const origin = "https://www.youtube.com"
 
 var Log format.Log
 
+// Address is a parsed watch address.
+type Address struct {
+   Video_ID string
+   Start time.Duration
+   Playlist_ID string
+}
+
+var video_ID = regexp.MustCompile(`^[\w-]{11}$`)
+
 // https://youtube.com/shorts/9Vsdft81Q6w
 // https://youtube.com/watch?v=XY-hOqcPGCY
-func Video_ID(raw_addr string) (string, error) {
+// https://m.youtube.com/watch?feature=share&v=XY-hOqcPGCY
+// https://www.youtube.com/embed/XY-hOqcPGCY?start=90
+// https://www.youtube.com/live/XY-hOqcPGCY
+// https://www.youtube.com/v/XY-hOqcPGCY
+// https://youtu.be/XY-hOqcPGCY?t=1m30s
+// https://www.youtube.com/attribution_link?u=/watch%3Fv%3DXY-hOqcPGCY
+func New_Address(raw_addr string) (*Address, error) {
+   if video_ID.MatchString(raw_addr) {
+      return &Address{Video_ID: raw_addr}, nil
+   }
+   if !strings.Contains(raw_addr, "://") {
+      raw_addr = "https://" + raw_addr
+   }
    addr, err := url.Parse(raw_addr)
    if err != nil {
-      return "", err
+      return nil, err
+   }
+   query := addr.Query()
+   if addr.Path == "/attribution_link" {
+      return New_Address(origin + query.Get("u"))
+   }
+   var out Address
+   out.Video_ID = query.Get("v")
+   if out.Video_ID == "" {
+      parts := strings.Split(strings.Trim(addr.Path, "/"), "/")
+      switch {
+      case addr.Hostname() == "youtu.be":
+         out.Video_ID = parts[0]
+      case len(parts) == 2:
+         switch parts[0] {
+         case "e", "embed", "live", "shorts", "v":
+            out.Video_ID = parts[1]
+         }
+      }
+   }
+   if !video_ID.MatchString(out.Video_ID) {
+      return nil, errors.New("invalid video ID " + strconv.Quote(out.Video_ID))
    }
-   v := addr.Query().Get("v")
-   if v != "" {
-      return v, nil
+   out.Playlist_ID = query.Get("list")
+   start := query.Get("t")
+   if start == "" {
+      start = query.Get("start")
+   }
+   if start == "" {
+      // youtube.com/watch?v=XY-hOqcPGCY#t=90
+      fragment, err := url.ParseQuery(addr.Fragment)
+      if err == nil {
+         start = fragment.Get("t")
+      }
+   }
+   // ignore a start time we cannot read, like 1m30
+   if start, err := parse_start(start); err == nil {
+      out.Start = start
+   }
+   return &out, nil
+}
+
+// 90, 90s, 1m30s, 1h2m3s
+func parse_start(s string) (time.Duration, error) {
+   if _, err := strconv.Atoi(s); err == nil {
+      s += "s"
+   }
+   return time.ParseDuration(s)
+}
+
+func Video_ID(raw_addr string) (string, error) {
+   addr, err := New_Address(raw_addr)
+   if err != nil {
+      return "", err
    }
-   return path.Base(addr.Path), nil
+   return addr.Video_ID, nil
 }
 
 type Image struct {
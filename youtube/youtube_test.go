@@ -23,3 +23,37 @@ func Test_Image(t *testing.T) {
       time.Sleep(99 * time.Millisecond)
    }
 }
+
+var addresses = map[string]Address{
+   "UpNXI3_ctAc": {Video_ID: id},
+   "https://youtube.com/shorts/UpNXI3_ctAc": {Video_ID: id},
+   "https://m.youtube.com/watch?feature=share&v=UpNXI3_ctAc": {Video_ID: id},
+   "https://www.youtube.com/embed/UpNXI3_ctAc?start=90": {
+      Video_ID: id, Start: 90 * time.Second,
+   },
+   "https://www.youtube.com/live/UpNXI3_ctAc": {Video_ID: id},
+   "https://www.youtube.com/live/UpNXI3_ctAc?t=1m30": {Video_ID: id},
+   "https://www.youtube.com/v/UpNXI3_ctAc": {Video_ID: id},
+   "youtu.be/UpNXI3_ctAc?t=1m30s": {Video_ID: id, Start: 90 * time.Second},
+   "https://www.youtube.com/watch?v=UpNXI3_ctAc&list=PL123#t=5": {
+      Video_ID: id, Start: 5 * time.Second, Playlist_ID: "PL123",
+   },
+   "https://www.youtube.com/attribution_link?u=/watch%3Fv%3DUpNXI3_ctAc%26feature%3Dshare": {
+      Video_ID: id,
+   },
+}
+
+func Test_Address(t *testing.T) {
+   for raw, want := range addresses {
+      addr, err := New_Address(raw)
+      if err != nil {
+         t.Fatal(raw, err)
+      }
+      if *addr != want {
+         t.Fatal(raw, addr)
+      }
+   }
+   if _, err := New_Address("https://www.youtube.com/embed/short"); err == nil {
+      t.Fatal("short ID")
+   }
+}